@@ -1,11 +1,11 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
-	"io"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"sort"
@@ -15,11 +15,24 @@ import (
 
 	"github.com/WavefrontHQ/go-wavefront-management-api"
 	"github.com/keep94/toolbox/http_util"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+
+	"github.com/keep94/wavefront_promql_proxy/grpcserver"
+	"github.com/keep94/wavefront_promql_proxy/internal/cache"
+	"github.com/keep94/wavefront_promql_proxy/internal/promapi"
+	"github.com/keep94/wavefront_promql_proxy/internal/scheduler"
 )
 
 var (
-	fPort string
-	fSkew time.Duration
+	fPort             string
+	fGRPCPort         string
+	fSkew             time.Duration
+	fLookback         time.Duration
+	fSchedulerConfig  string
+	fSchedulerWorkers int
+	fCacheBoundary    time.Duration
+	fCacheSize        int
 )
 
 func main() {
@@ -33,320 +46,405 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	http.Handle("/api/v1/query_range", &queryHandler{
-		client: client,
-		skew:   fSkew,
-	})
-	if err := http.ListenAndServe(fPort, http.DefaultServeMux); err != nil {
+	sched, err := newScheduler()
+	if err != nil {
+		log.Fatal(err)
+	}
+	queryCache := cache.New(cache.NewLRU(fCacheSize), fCacheBoundary)
+	routes := []struct {
+		path    string
+		handler http.Handler
+	}{
+		{"/api/v1/query_range", &queryRangeHandler{client: client, skew: fSkew, lookback: fLookback, scheduler: sched, cache: queryCache}},
+		{"/api/v1/query", &instantQueryHandler{client: client, skew: fSkew, lookback: fLookback}},
+		{"/api/v1/series", &seriesHandler{client: client}},
+		{"/api/v1/labels", &labelsHandler{client: client}},
+		{"/api/v1/label/", &labelValuesHandler{client: client}},
+		{"/api/v1/metadata", &metadataHandler{client: client}},
+	}
+	mux := http.NewServeMux()
+	for _, route := range routes {
+		mux.Handle(route.path, withCORS(route.handler))
+	}
+	mux.Handle("/metrics", promhttp.Handler())
+
+	go serveGRPC(client)
+
+	if err := http.ListenAndServe(fPort, mux); err != nil {
 		fmt.Println(err)
 	}
 }
 
-type queryHandler struct {
-	client *wavefront.Client
-	skew   time.Duration
+// serveGRPC listens on -grpc and serves the query.v1.Query gRPC API on a
+// second listener alongside the HTTP API, sharing the same Wavefront
+// client.
+func serveGRPC(client *wavefront.Client) {
+	lis, err := net.Listen("tcp", fGRPCPort)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := grpc.NewServer()
+	grpcserver.Register(s, client, fSkew, fLookback)
+	if err := s.Serve(lis); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// newScheduler builds the Scheduler that fronts query_range, loading
+// priority rules from -scheduler-config if one was given.
+func newScheduler() (*scheduler.Scheduler, error) {
+	config := &scheduler.Config{}
+	if fSchedulerConfig != "" {
+		var err error
+		config, err = scheduler.LoadConfig(fSchedulerConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return scheduler.New(config, fSchedulerWorkers)
 }
 
-func (h *queryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+// withCORS wraps h so that browser based clients such as Grafana can call
+// this proxy directly. It answers preflight OPTIONS requests itself and
+// adds the permissive CORS headers Prometheus' own API server sends on
+// every other response.
+func withCORS(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, Origin")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		if r.Method == "OPTIONS" {
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// queryRangeHandler answers /api/v1/query_range. Before forwarding to
+// Wavefront, it admits the request through a Scheduler so that a burst of
+// expensive dashboard queries cannot exhaust the Wavefront rate limit and
+// starve cheap alerting queries behind them.
+type queryRangeHandler struct {
+	client    *wavefront.Client
+	skew      time.Duration
+	lookback  time.Duration
+	scheduler *scheduler.Scheduler
+	cache     *cache.Cache
+}
+
+func (h *queryRangeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" && r.Method != "POST" {
 		http_util.Error(w, http.StatusMethodNotAllowed)
 		return
 	}
 	r.ParseForm()
-	promQL, err := extractPromQL(r)
-	if err != nil {
-		writeError(w, err)
+	params, apiErr := promapi.ParseQueryRange(r)
+	if apiErr != nil {
+		promapi.WriteError(w, apiErr)
 		return
 	}
-	wavefrontQuery, err := h.convertToWavefrontAndSkewEarlier(promQL)
+
+	tenant := r.Header.Get(h.scheduler.TenantHeader())
+	if tenant == "" {
+		tenant = "anonymous"
+	}
+	priority, timeout := h.scheduler.Classify(params.Query, params.Start, params.End)
+	release, err := h.scheduler.Schedule(r.Context(), tenant, priority, timeout)
 	if err != nil {
-		writeError(w, err)
+		schedErr := err.(*scheduler.SchedulingError)
+		promapi.WriteError(w, &promapi.APIError{ErrorType: schedErr.Reason, Err: schedErr.Error()})
 		return
 	}
-	wavefrontResult, err := h.sendToWavefrontAndSkewLater(wavefrontQuery)
+	defer release()
+
+	wavefrontResult, err := h.cache.Get(r.Context(), params.Query, params.Step, params.Start, params.End,
+		func(ctx context.Context, start, end float64) (*wavefront.QueryResponse, error) {
+			wavefrontQuery := promapi.BuildWavefrontQuery(params.Query, start, end, h.skew)
+			return promapi.SendToWavefront(h.client, wavefrontQuery, h.skew)
+		})
 	if err != nil {
-		writeError(w, err)
+		promapi.WriteError(w, err)
 		return
 	}
-	promQLResult, err := convertFromWavefront(wavefrontResult, promQL)
-	if err != nil {
-		writeError(w, err)
+	result, apiErr := promapi.ConvertFromWavefront(wavefrontResult, params, h.lookback)
+	if apiErr != nil {
+		promapi.WriteError(w, apiErr)
 		return
 	}
-	encoder := json.NewEncoder(w)
-	encoder.Encode(&promQLResult)
+	promapi.WriteJSON(w, &promapi.QueryRangeData{
+		ResultType: "matrix",
+		Result:     result,
+	})
 }
 
-func (h *queryHandler) convertToWavefrontAndSkewEarlier(
-	query *promQLQuery) (*wavefrontQuery, error) {
-
-	skew := float64(h.skew) / float64(time.Second)
-
-	// We set the wavefront start time to be 15s before the promQL start time.
-	// We do this because otherwise, the first Wavefront data point may be
-	// after start time, and we won't get the correct value for start time.
-	// This isn't perfect as there is no guarantee that going 15s back is
-	// sufficient.
-	s := strconv.FormatInt(int64((query.Start-15.0-skew)*1000), 10)
-
-	// In promQL, end time is inclusive, but in Wavefront it is exclusive.
-	// In wavefront times have to be at 1000ms less than end time.
-	e := strconv.FormatInt(int64((query.End+1.0-skew)*1000), 10)
-
-	// Here we set g=s to get a step of one second from wavefront. Later
-	// we will apply the step parameter from promQL when converting the
-	// response back to promQL.
-	return &wavefrontQuery{
-		Q: query.Query,
-		S: s,
-		E: e,
-		G: "s",
-	}, nil
+// instantQueryHandler answers /api/v1/query. It reports the most recent
+// Wavefront datapoint at or before the requested time for each series.
+type instantQueryHandler struct {
+	client   *wavefront.Client
+	skew     time.Duration
+	lookback time.Duration
 }
 
-func (h *queryHandler) sendToWavefrontAndSkewLater(query *wavefrontQuery) (
-	*wavefront.QueryResponse, error) {
-	qp := wavefront.NewQueryParams(query.Q)
-	qp.StartTime = query.S
-	qp.EndTime = query.E
-	qp.Granularity = query.G
-	q := h.client.NewQuery(qp)
-	response, err := q.Execute()
-	if err != nil {
-		return nil, err
+func (h *instantQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" {
+		http_util.Error(w, http.StatusMethodNotAllowed)
+		return
 	}
-	return h.skewLater(response), nil
-}
-
-func (h *queryHandler) skewLater(
-	response *wavefront.QueryResponse) *wavefront.QueryResponse {
-
-	skew := float64(h.skew) / float64(time.Second)
-
-	for i := range response.TimeSeries {
-		for j := range response.TimeSeries[i].DataPoints {
-			response.TimeSeries[i].DataPoints[j][0] += skew
-		}
+	r.ParseForm()
+	params, apiErr := promapi.ParseInstantQuery(r)
+	if apiErr != nil {
+		promapi.WriteError(w, apiErr)
+		return
 	}
-	return response
-}
 
-func extractPromQL(r *http.Request) (*promQLQuery, error) {
-	startStr := r.Form.Get("start")
-	start, err := strconv.ParseFloat(startStr, 64)
+	// We look back far enough to find the last reported value even if the
+	// series reports infrequently, but no further than -lookback, the same
+	// staleness window Prometheus itself uses.
+	wavefrontQuery := promapi.BuildWavefrontQuery(params.Query, params.Time-h.lookback.Seconds(), params.Time, h.skew)
+	wavefrontResult, err := promapi.SendToWavefront(h.client, wavefrontQuery, h.skew)
 	if err != nil {
-		return nil, newBadDataPromQLError(
-			fmt.Sprintf("invalid parameter 'start': cannot parse \"%s\" to a valid timestamp", startStr))
+		promapi.WriteError(w, err)
+		return
 	}
-	endStr := r.Form.Get("end")
-	end, err := strconv.ParseFloat(endStr, 64)
-	if err != nil {
-		return nil, newBadDataPromQLError(
-			fmt.Sprintf("invalid parameter 'end': cannot parse \"%s\" to a valid timestamp", endStr))
+	if wavefrontResult.ErrType != "" {
+		promapi.WriteError(w, promapi.NewBadDataError(wavefrontResult.ErrMessage))
+		return
 	}
-	stepStr := r.Form.Get("step")
-	step, err := strconv.ParseFloat(stepStr, 64)
-	if err != nil {
-		return nil, newBadDataPromQLError(
-			fmt.Sprintf("invalid parameter 'step': cannot parse \"%s\" to a valid duration", stepStr))
-	}
-	if step <= 0.0 {
-		return nil, newBadDataPromQLError(
-			"zero or negative query resolution step widths are not accepted. Try a positive integer")
-	}
-	if end < start {
-		return nil, newBadDataPromQLError(
-			"end timestamp must not be before start time")
-	}
-	return &promQLQuery{
-		Start: start,
-		End:   end,
-		Step:  step,
-		Query: r.Form.Get("query"),
-	}, nil
-}
-
-func newBadDataPromQLError(str string) *promQLError {
-	return &promQLError{
-		Status:    "error",
-		ErrorType: "bad_data",
-		Err:       str,
+	result := make([]promapi.VectorSample, 0, len(wavefrontResult.TimeSeries))
+	for i := range wavefrontResult.TimeSeries {
+		sample, ok := promapi.LastDataPointAtOrBefore(wavefrontResult.TimeSeries[i].DataPoints, params.Time)
+		if !ok {
+			continue
+		}
+		result = append(result, promapi.VectorSample{
+			Metric: promapi.ExtractMetric(&wavefrontResult.TimeSeries[i]),
+			Value:  sample,
+		})
 	}
+	promapi.WriteJSON(w, &promapi.InstantQueryData{
+		ResultType: "vector",
+		Result:     result,
+	})
 }
 
-func writeError(w http.ResponseWriter, err error) {
-	w.WriteHeader(400)
-	io.Copy(w, strings.NewReader(err.Error()))
-}
-
-func convertFromWavefront(
-	response *wavefront.QueryResponse, query *promQLQuery) (
-	*promQLResponse, error) {
-	if response.ErrType != "" {
-		return nil, newBadDataPromQLError(response.ErrMessage)
-	}
-	var result promQLResponse
-	result.Status = "success"
-	result.Data.ResultType = "matrix"
-	result.Data.Result = make([]promQLTimeSeries, len(response.TimeSeries))
-	for i := range response.TimeSeries {
-		result.Data.Result[i].Metric = extractPromQLMetric(&response.TimeSeries[i])
-		result.Data.Result[i].Values = extractPromQLData(
-			response.TimeSeries[i].DataPoints, query)
-	}
-	sortTimeSeriesInPlace(result.Data.Result)
-	return &result, nil
+// seriesHandler answers /api/v1/series, returning the label sets of the
+// series matched by one or more match[] selectors without any values.
+type seriesHandler struct {
+	client *wavefront.Client
 }
 
-func extractPromQLMetric(t *wavefront.TimeSeries) map[string]string {
-	result := make(map[string]string)
-	if t.Label != "" {
-		result["__name__"] = t.Label
-	}
-	if t.Host != "" {
-		// TODO: If there is a "instance" tag, this will get clobbered
-		result["instance"] = t.Host
+func (h *seriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" {
+		http_util.Error(w, http.StatusMethodNotAllowed)
+		return
 	}
-	for k, v := range t.Tags {
-		result[k] = v
+	r.ParseForm()
+	params, apiErr := promapi.ParseSeries(r, -time.Hour)
+	if apiErr != nil {
+		promapi.WriteError(w, apiErr)
+		return
 	}
-	return result
-}
-
-func floatToString(x float64) string {
-	return strconv.FormatFloat(x, 'g', -1, 64)
-}
-
-// Here we are trying to simulate the step functionality of promQL. While
-// this code works most of the time, it is not perfect because the
-// wavefront data itself has granularity of 1s, 5s, or whatever. It really
-// isn't possible to tell what the value is at an arbitrary time. What we
-// do here, is we just assume that the last reported data value is correct,
-// but this may or may not be the case.
-func extractPromQLData(
-	data []wavefront.DataPoint, query *promQLQuery) [][2]interface{} {
-	if len(data) == 0 {
-		return make([][2]interface{}, 0)
-	}
-	resultSize := int((query.End-query.Start)/query.Step) + 1
-	var result [][2]interface{}
-	indexPlus1 := 1
-	for i := 0; i < resultSize; i++ {
-		timestamp := query.Start + float64(i)*query.Step
-		for indexPlus1 < len(data) && data[indexPlus1][0] <= timestamp {
-			indexPlus1++
+	var result []map[string]string
+	for _, matcher := range params.Matchers {
+		selector, parseErr := promapi.ParseSelector(matcher)
+		if parseErr != nil {
+			promapi.WriteError(w, promapi.NewBadDataError(
+				"invalid parameter 'match[]': %v", parseErr))
+			return
+		}
+		query, parseErr := selector.ToWavefrontQuery()
+		if parseErr != nil {
+			promapi.WriteError(w, promapi.NewBadDataError(
+				"invalid parameter 'match[]': %v", parseErr))
+			return
+		}
+		response, err := runWavefrontQuery(h.client, query, params.Start, params.End)
+		if err != nil {
+			promapi.WriteError(w, err)
+			return
 		}
-		timestampdiff := timestamp - data[indexPlus1-1][0]
-		if timestampdiff >= 0 && timestampdiff < query.Step {
-			result = append(result, [2]interface{}{
-				timestamp, floatToString(data[indexPlus1-1][1])})
+		if response.ErrType != "" {
+			promapi.WriteError(w, promapi.NewBadDataError(response.ErrMessage))
+			return
+		}
+		for i := range response.TimeSeries {
+			result = append(result, promapi.ExtractMetric(&response.TimeSeries[i]))
 		}
 	}
-	return result
+	promapi.WriteJSON(w, result)
 }
 
-type promQLQuery struct {
-	Start float64
-	End   float64
-	Step  float64
-	Query string
-}
-
-type wavefrontQuery struct {
-	Q string
-	S string
-	E string
-	G string
-}
-
-type promQLResponse struct {
-	Data   promQLData `json:"data"`
-	Status string     `json:"status"`
-}
-
-type promQLData struct {
-	Result     []promQLTimeSeries `json:"result"`
-	ResultType string             `json:"resultType"`
+// labelsHandler answers /api/v1/labels, enumerating all the label names
+// seen across every series reporting in [start, end].
+type labelsHandler struct {
+	client *wavefront.Client
 }
 
-type promQLTimeSeries struct {
-	Metric map[string]string `json:"metric"`
-	Values [][2]interface{}  `json:"values"`
+func (h *labelsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" {
+		http_util.Error(w, http.StatusMethodNotAllowed)
+		return
+	}
+	r.ParseForm()
+	start, end, apiErr := promapi.ParseStartEnd(r, -time.Hour)
+	if apiErr != nil {
+		promapi.WriteError(w, apiErr)
+		return
+	}
+	response, err := runWavefrontQuery(h.client, "ts(*)", start, end)
+	if err != nil {
+		promapi.WriteError(w, err)
+		return
+	}
+	if response.ErrType != "" {
+		promapi.WriteError(w, promapi.NewBadDataError(response.ErrMessage))
+		return
+	}
+	labelSet := make(map[string]bool)
+	labelSet["__name__"] = true
+	for i := range response.TimeSeries {
+		for k := range promapi.ExtractMetric(&response.TimeSeries[i]) {
+			labelSet[k] = true
+		}
+	}
+	promapi.WriteJSON(w, labelNamesFromSet(labelSet))
 }
 
-type promQLError struct {
-	Status    string `json:"status"`
-	ErrorType string `json:"errorType"`
-	Err       string `json:"error"`
+// labelValuesHandler answers /api/v1/label/<name>/values.
+type labelValuesHandler struct {
+	client *wavefront.Client
 }
 
-func (p *promQLError) Error() string {
-	jsonBytes, err := json.Marshal(p)
+func (h *labelValuesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" {
+		http_util.Error(w, http.StatusMethodNotAllowed)
+		return
+	}
+	name, ok := extractLabelName(r.URL.Path)
+	if !ok {
+		promapi.WriteError(w, promapi.NewBadDataError(
+			"invalid label name path, expected /api/v1/label/<name>/values"))
+		return
+	}
+	r.ParseForm()
+	start, end, apiErr := promapi.ParseStartEnd(r, -time.Hour)
+	if apiErr != nil {
+		promapi.WriteError(w, apiErr)
+		return
+	}
+	response, err := runWavefrontQuery(h.client, "ts(*)", start, end)
 	if err != nil {
-		return err.Error()
+		promapi.WriteError(w, err)
+		return
 	}
-	return string(jsonBytes)
-}
-
-func sortTimeSeriesInPlace(timeSeries []promQLTimeSeries) {
-	sorter := promQLTimeSeriesSorter{timeSeries: timeSeries}
-	sorter.initialize()
-	sort.Sort(&sorter)
+	if response.ErrType != "" {
+		promapi.WriteError(w, promapi.NewBadDataError(response.ErrMessage))
+		return
+	}
+	valueSet := make(map[string]bool)
+	for i := range response.TimeSeries {
+		if value, ok := promapi.ExtractMetric(&response.TimeSeries[i])[name]; ok {
+			valueSet[value] = true
+		}
+	}
+	promapi.WriteJSON(w, labelNamesFromSet(valueSet))
 }
 
-type promQLTimeSeriesSorter struct {
-	timeSeries      []promQLTimeSeries
-	metricKeyValues [][]string
+// metadataHandler answers /api/v1/metadata with a best effort metadata
+// entry for every distinct metric name seen; Wavefront has no concept of
+// metric type, help text, or unit, so those fields are always reported
+// as unknown.
+type metadataHandler struct {
+	client *wavefront.Client
 }
 
-func (p *promQLTimeSeriesSorter) initialize() {
-	p.metricKeyValues = make([][]string, len(p.timeSeries))
-	for i := range p.timeSeries {
-		p.metricKeyValues[i] = metricMapToSlice(p.timeSeries[i].Metric)
+func (h *metadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" {
+		http_util.Error(w, http.StatusMethodNotAllowed)
+		return
 	}
+	r.ParseForm()
+	start, end, apiErr := promapi.ParseStartEnd(r, -time.Hour)
+	if apiErr != nil {
+		promapi.WriteError(w, apiErr)
+		return
+	}
+	response, err := runWavefrontQuery(h.client, "ts(*)", start, end)
+	if err != nil {
+		promapi.WriteError(w, err)
+		return
+	}
+	if response.ErrType != "" {
+		promapi.WriteError(w, promapi.NewBadDataError(response.ErrMessage))
+		return
+	}
+	result := make(map[string][]metricMetadata)
+	for i := range response.TimeSeries {
+		name := response.TimeSeries[i].Label
+		if name == "" {
+			continue
+		}
+		if _, ok := result[name]; ok {
+			continue
+		}
+		result[name] = []metricMetadata{{Type: "gauge"}}
+	}
+	promapi.WriteJSON(w, result)
 }
 
-func (p *promQLTimeSeriesSorter) Less(i, j int) bool {
-	return sliceLess(p.metricKeyValues[i], p.metricKeyValues[j])
-}
-
-func (p *promQLTimeSeriesSorter) Swap(i, j int) {
-	p.metricKeyValues[i], p.metricKeyValues[j] = p.metricKeyValues[j], p.metricKeyValues[i]
-	p.timeSeries[i], p.timeSeries[j] = p.timeSeries[j], p.timeSeries[i]
+type metricMetadata struct {
+	Type string `json:"type"`
+	Help string `json:"help"`
+	Unit string `json:"unit"`
 }
 
-func (p *promQLTimeSeriesSorter) Len() int {
-	return len(p.timeSeries)
+func labelNamesFromSet(set map[string]bool) []string {
+	result := make([]string, 0, len(set))
+	for name := range set {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
 }
 
-func metricMapToSlice(metric map[string]string) []string {
-	keys := make([]string, 0, len(metric))
-	for key := range metric {
-		keys = append(keys, key)
+func extractLabelName(path string) (string, bool) {
+	const prefix = "/api/v1/label/"
+	const suffix = "/values"
+	if !strings.HasPrefix(path, prefix) || !strings.HasSuffix(path, suffix) {
+		return "", false
 	}
-	sort.Strings(keys)
-	result := make([]string, 0, 2*len(metric))
-	for _, key := range keys {
-		result = append(result, key, metric[key])
+	name := strings.TrimSuffix(strings.TrimPrefix(path, prefix), suffix)
+	if name == "" {
+		return "", false
 	}
-	return result
+	return name, true
 }
 
-func sliceLess(lhs, rhs []string) bool {
-	i := 0
-	for i < len(lhs) && i < len(rhs) {
-		if lhs[i] < rhs[i] {
-			return true
-		}
-		if lhs[i] > rhs[i] {
-			return false
-		}
-		i++
-	}
-	return len(lhs) < len(rhs)
+// runWavefrontQuery runs query against Wavefront over [start, end] at one
+// second granularity, the same granularity queryRangeHandler uses.
+func runWavefrontQuery(client *wavefront.Client, query string, start, end float64) (
+	*wavefront.QueryResponse, error) {
+
+	qp := wavefront.NewQueryParams(query)
+	qp.StartTime = strconv.FormatInt(int64(start*1000), 10)
+	qp.EndTime = strconv.FormatInt(int64(end*1000), 10)
+	qp.Granularity = "s"
+	q := client.NewQuery(qp)
+	return q.Execute()
 }
 
 func init() {
 	flag.StringVar(&fPort, "http", ":9090", "Port to bind")
+	flag.StringVar(&fGRPCPort, "grpc", ":9091", "Port to bind the gRPC Query API to")
 	flag.DurationVar(&fSkew, "skew", 0, "Amount of time wavefront is earlier")
+	flag.DurationVar(&fLookback, "lookback", 5*time.Minute,
+		"How stale a Wavefront datapoint may be and still answer a query, matching Prometheus' staleness window")
+	flag.StringVar(&fSchedulerConfig, "scheduler-config", "",
+		"Path to a YAML file of scheduler priority rules. If empty, all query_range requests get the same priority.")
+	flag.DurationVar(&fCacheBoundary, "cache-boundary", time.Hour,
+		"Width of the aligned sub-intervals query_range results are cached under")
+	flag.IntVar(&fCacheSize, "cache-size", 10000,
+		"Maximum number of cached query_range intervals to keep in memory")
+	flag.IntVar(&fSchedulerWorkers, "scheduler-workers", 32,
+		"Total number of query_range requests that may be in flight to Wavefront at once")
 }
@@ -0,0 +1,15 @@
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+	"google.golang.org/grpc"
+
+	queryv1 "github.com/keep94/wavefront_promql_proxy/proto/query/v1"
+)
+
+// Register registers a Server backed by client on s.
+func Register(s *grpc.Server, client *wavefront.Client, skew, lookback time.Duration) {
+	queryv1.RegisterQueryServer(s, &Server{Client: client, Skew: skew, Lookback: lookback})
+}
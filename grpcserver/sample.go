@@ -0,0 +1,11 @@
+package grpcserver
+
+import "strconv"
+
+// parseSampleValue parses the string-encoded sample values
+// promapi.ExtractMatrixData and promapi.LastDataPointAtOrBefore produce
+// back into a float64 for the gRPC wire format, which carries values as
+// doubles rather than strings.
+func parseSampleValue(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,17 @@
+package grpcserver
+
+import "testing"
+
+func TestParseSampleValue(t *testing.T) {
+	got, err := parseSampleValue("3.5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 3.5 {
+		t.Errorf("parseSampleValue(\"3.5\") = %v; want 3.5", got)
+	}
+
+	if _, err := parseSampleValue("not-a-number"); err == nil {
+		t.Fatal("expected error for malformed value")
+	}
+}
@@ -0,0 +1,131 @@
+// Package grpcserver implements the query.v1.Query gRPC service, letting
+// upstream queriers fan out PromQL queries to this proxy without going
+// through its HTTP API.
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+
+	"github.com/keep94/wavefront_promql_proxy/internal/promapi"
+	queryv1 "github.com/keep94/wavefront_promql_proxy/proto/query/v1"
+)
+
+// Server implements queryv1.QueryServer, answering queries the same way
+// the HTTP /api/v1/query and /api/v1/query_range handlers do: by
+// translating them to a Wavefront query and running them through the
+// shared promapi conversion pipeline.
+type Server struct {
+	queryv1.UnimplementedQueryServer
+
+	Client   *wavefront.Client
+	Skew     time.Duration
+	Lookback time.Duration
+}
+
+// Query implements queryv1.QueryServer.
+func (s *Server) Query(req *queryv1.QueryRequest, stream queryv1.Query_QueryServer) error {
+	start := time.Now()
+
+	// We look back far enough to find the last reported value even if the
+	// series reports infrequently, but no further than s.Lookback, the
+	// same staleness window Prometheus itself uses.
+	wavefrontQuery := promapi.BuildWavefrontQuery(req.Query, req.Time-s.Lookback.Seconds(), req.Time, s.Skew)
+	response, err := promapi.SendToWavefront(s.Client, wavefrontQuery, s.Skew)
+	if err != nil {
+		return err
+	}
+	if response.ErrType != "" {
+		return stream.Send(&queryv1.QueryResponse{
+			Result: &queryv1.QueryResponse_Warning{Warning: response.ErrMessage},
+		})
+	}
+
+	sent := 0
+	for i := range response.TimeSeries {
+		sample, ok := promapi.LastDataPointAtOrBefore(response.TimeSeries[i].DataPoints, req.Time)
+		if !ok {
+			continue
+		}
+		timestamp := sample[0].(float64)
+		floatValue, perr := parseSampleValue(sample[1].(string))
+		if perr != nil {
+			return perr
+		}
+		if err := stream.Send(&queryv1.QueryResponse{
+			Result: &queryv1.QueryResponse_Series{
+				Series: &queryv1.Series{
+					Labels:  promapi.ExtractMetric(&response.TimeSeries[i]),
+					Samples: []*queryv1.Sample{{Timestamp: timestamp, Value: floatValue}},
+				},
+			},
+		}); err != nil {
+			return err
+		}
+		sent++
+	}
+
+	return stream.Send(&queryv1.QueryResponse{
+		Result: &queryv1.QueryResponse_Stats{
+			Stats: &queryv1.Stats{
+				SeriesReturned:       int64(sent),
+				ExecutionTimeSeconds: time.Since(start).Seconds(),
+			},
+		},
+	})
+}
+
+// QueryRange implements queryv1.QueryServer.
+func (s *Server) QueryRange(req *queryv1.QueryRangeRequest, stream queryv1.Query_QueryRangeServer) error {
+	start := time.Now()
+
+	params := &promapi.QueryRangeParams{
+		Start: req.Start,
+		End:   req.End,
+		Step:  req.Step,
+		Query: req.Query,
+	}
+	wavefrontQuery := promapi.BuildWavefrontQuery(params.Query, params.Start, params.End, s.Skew)
+	response, err := promapi.SendToWavefront(s.Client, wavefrontQuery, s.Skew)
+	if err != nil {
+		return err
+	}
+	result, apiErr := promapi.ConvertFromWavefront(response, params, s.Lookback)
+	if apiErr != nil {
+		return stream.Send(&queryv1.QueryRangeResponse{
+			Result: &queryv1.QueryRangeResponse_Warning{Warning: apiErr.Error()},
+		})
+	}
+
+	for i := range result {
+		samples := make([]*queryv1.Sample, len(result[i].Values))
+		for j, v := range result[i].Values {
+			timestamp := v[0].(float64)
+			value, perr := parseSampleValue(v[1].(string))
+			if perr != nil {
+				return perr
+			}
+			samples[j] = &queryv1.Sample{Timestamp: timestamp, Value: value}
+		}
+		if err := stream.Send(&queryv1.QueryRangeResponse{
+			Result: &queryv1.QueryRangeResponse_Series{
+				Series: &queryv1.Series{
+					Labels:  result[i].Metric,
+					Samples: samples,
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return stream.Send(&queryv1.QueryRangeResponse{
+		Result: &queryv1.QueryRangeResponse_Stats{
+			Stats: &queryv1.Stats{
+				SeriesReturned:       int64(len(result)),
+				ExecutionTimeSeconds: time.Since(start).Seconds(),
+			},
+		},
+	})
+}
@@ -0,0 +1,16 @@
+// Package cache splits Wavefront query_range calls into sub-intervals
+// aligned to a fixed boundary, caches the Wavefront response for every
+// complete sub-interval, and coalesces concurrent requests for the same
+// uncached interval into a single upstream call.
+package cache
+
+import "github.com/WavefrontHQ/go-wavefront-management-api"
+
+// Backend stores the Wavefront response for a single cache key, as
+// produced by Key. The zero value of no implementation is usable; callers
+// get one from NewLRU or provide their own, e.g. backed by Redis or
+// memcache.
+type Backend interface {
+	Get(key string) (*wavefront.QueryResponse, bool)
+	Set(key string, value *wavefront.QueryResponse)
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+)
+
+// FetchFunc asks Wavefront for query's data over [start, end). It is the
+// caller's job to translate this into a Wavefront query, e.g. via
+// promapi.BuildWavefrontQuery and promapi.SendToWavefront; this package
+// stays agnostic of the Wavefront client so it can be tested without one.
+type FetchFunc func(ctx context.Context, start, end float64) (*wavefront.QueryResponse, error)
+
+// Cache splits a query_range request into sub-intervals aligned to
+// boundary, answers the complete, already-elapsed ones from backend, and
+// only calls fetch for the trailing interval(s) that aren't cached yet.
+type Cache struct {
+	backend  Backend
+	boundary float64
+	group    singleflight.Group
+}
+
+// New returns a Cache storing complete intervals in backend, each
+// boundary seconds wide.
+func New(backend Backend, boundary time.Duration) *Cache {
+	return &Cache{backend: backend, boundary: boundary.Seconds()}
+}
+
+// Get returns the Wavefront response for query over [start, end) at the
+// given step, reusing cached intervals and coalescing concurrent requests
+// for the same uncached interval via fetch.
+func (c *Cache) Get(
+	ctx context.Context, query string, step, start, end float64, fetch FetchFunc) (*wavefront.QueryResponse, error) {
+
+	intervals := AlignedIntervals(start, end, c.boundary)
+	if len(intervals) == 0 {
+		return &wavefront.QueryResponse{}, nil
+	}
+	now := float64(time.Now().Unix())
+
+	pieces := make([]*wavefront.QueryResponse, 0, len(intervals))
+	firstUncached := len(intervals)
+	for i, interval := range intervals {
+		if interval.End > now {
+			firstUncached = i
+			break
+		}
+		cached, ok := c.backend.Get(Key(query, step, interval.Start))
+		if !ok {
+			firstUncached = i
+			break
+		}
+		hitsTotal.Inc()
+		pieces = append(pieces, cached)
+	}
+
+	if firstUncached < len(intervals) {
+		missesTotal.Add(float64(len(intervals) - firstUncached))
+		fetchStart := intervals[firstUncached].Start
+		sfKey := fmt.Sprintf("%s:%v", Key(query, step, fetchStart), end)
+		v, err, _ := c.group.Do(sfKey, func() (interface{}, error) {
+			return fetch(ctx, fetchStart, end)
+		})
+		if err != nil {
+			return nil, err
+		}
+		response := v.(*wavefront.QueryResponse)
+		for i := firstUncached; i < len(intervals); i++ {
+			interval := intervals[i]
+			lowerBound := interval.Start
+			if i == 0 {
+				// BuildWavefrontQuery deliberately asks Wavefront for a few
+				// seconds before the request's true Start so that
+				// ExtractMatrixData has a datapoint to report at Start
+				// itself even when Wavefront's native sample doesn't land
+				// exactly on it. Clipping the leftmost piece to
+				// interval.Start would throw that margin away before it
+				// ever reaches ExtractMatrixData, silently dropping the
+				// leading sample from almost every query_range response.
+				lowerBound = math.Inf(-1)
+			}
+			piece := filterByRange(response, lowerBound, interval.End)
+			// A Wavefront in-band error (rate limit, overload, ...) is
+			// transient, not a property of the interval itself. Caching it
+			// would poison this key until LRU eviction, so every request
+			// for the interval would replay the same error instead of
+			// ever retrying Wavefront.
+			if interval.End <= now && response.ErrType == "" {
+				c.backend.Set(Key(query, step, interval.Start), piece)
+			}
+			pieces = append(pieces, piece)
+		}
+	}
+
+	return mergeResponses(pieces...), nil
+}
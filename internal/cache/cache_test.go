@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+
+	"github.com/keep94/wavefront_promql_proxy/internal/promapi"
+)
+
+func TestCacheReusesCompleteIntervals(t *testing.T) {
+	now := float64(time.Now().Unix())
+	start := now - 7200
+	end := now - 3600 // entirely in the past, so the whole range is cacheable
+
+	c := New(NewLRU(10), time.Hour)
+	fetchCount := 0
+	fetch := func(ctx context.Context, fetchStart, fetchEnd float64) (*wavefront.QueryResponse, error) {
+		fetchCount++
+		return &wavefront.QueryResponse{
+			TimeSeries: []wavefront.TimeSeries{
+				{Label: "cpu", DataPoints: []wavefront.DataPoint{{fetchStart, 1}, {fetchEnd - 1, 2}}},
+			},
+		}, nil
+	}
+
+	if _, err := c.Get(context.Background(), "ts(cpu)", 60, start, end, fetch); err != nil {
+		t.Fatalf("first Get: %v", err)
+	}
+	if fetchCount != 1 {
+		t.Fatalf("fetchCount after first Get = %d; want 1", fetchCount)
+	}
+
+	if _, err := c.Get(context.Background(), "ts(cpu)", 60, start, end, fetch); err != nil {
+		t.Fatalf("second Get: %v", err)
+	}
+	if fetchCount != 1 {
+		t.Errorf("fetchCount after second Get = %d; want 1 (should be served from cache)", fetchCount)
+	}
+}
+
+func TestCacheNeverCachesTrailingInterval(t *testing.T) {
+	now := float64(time.Now().Unix())
+	start := now - 60
+	end := now + 60 // the requested range's last interval is still in progress
+
+	c := New(NewLRU(10), time.Hour)
+	fetchCount := 0
+	fetch := func(ctx context.Context, fetchStart, fetchEnd float64) (*wavefront.QueryResponse, error) {
+		fetchCount++
+		return &wavefront.QueryResponse{}, nil
+	}
+
+	if _, err := c.Get(context.Background(), "ts(cpu)", 60, start, end, fetch); err != nil {
+		t.Fatalf("first Get: %v", err)
+	}
+	if _, err := c.Get(context.Background(), "ts(cpu)", 60, start, end, fetch); err != nil {
+		t.Fatalf("second Get: %v", err)
+	}
+	if fetchCount != 2 {
+		t.Errorf("fetchCount = %d; want 2 (the trailing interval must never be cached)", fetchCount)
+	}
+}
+
+func TestCacheDoesNotCacheWavefrontErrors(t *testing.T) {
+	now := float64(time.Now().Unix())
+	start := now - 7200
+	end := now - 3600 // entirely in the past, so the interval would normally be cached
+
+	c := New(NewLRU(10), time.Hour)
+	fetchCount := 0
+	fetch := func(ctx context.Context, fetchStart, fetchEnd float64) (*wavefront.QueryResponse, error) {
+		fetchCount++
+		return &wavefront.QueryResponse{ErrType: "error", ErrMessage: "rate limited"}, nil
+	}
+
+	if _, err := c.Get(context.Background(), "ts(cpu)", 60, start, end, fetch); err != nil {
+		t.Fatalf("first Get: %v", err)
+	}
+	if _, err := c.Get(context.Background(), "ts(cpu)", 60, start, end, fetch); err != nil {
+		t.Fatalf("second Get: %v", err)
+	}
+	if fetchCount != 2 {
+		t.Errorf("fetchCount = %d; want 2 (a Wavefront error must never be served from cache)", fetchCount)
+	}
+}
+
+func TestCacheKeepsLeadingMarginSampleForConvertFromWavefront(t *testing.T) {
+	// Align start to an hour boundary so the request maps to exactly one
+	// aligned interval, entirely in the past and therefore cacheable.
+	now := float64(time.Now().Unix())
+	start := math.Floor(now/3600)*3600 - 7200
+	step := 60.0
+	end := start + 2*step
+
+	c := New(NewLRU(10), time.Hour)
+	fetch := func(ctx context.Context, fetchStart, fetchEnd float64) (*wavefront.QueryResponse, error) {
+		// Mirror queryRangeHandler's fetch closure: ask
+		// BuildWavefrontQuery for the margin-adjusted Wavefront window and
+		// return data anchored to it, the way a real Wavefront response
+		// includes a datapoint from just before fetchStart.
+		wq := promapi.BuildWavefrontQuery("ts(cpu)", fetchStart, fetchEnd, 0)
+		s, err := strconv.ParseFloat(wq.S, 64)
+		if err != nil {
+			t.Fatalf("parse wavefront start: %v", err)
+		}
+		marginStart := s / 1000
+		return &wavefront.QueryResponse{
+			TimeSeries: []wavefront.TimeSeries{
+				{Label: "cpu", DataPoints: []wavefront.DataPoint{
+					{marginStart + 5, 1},
+					{fetchStart + step, 2},
+					{fetchStart + 2*step, 3},
+				}},
+			},
+		}, nil
+	}
+
+	response, err := c.Get(context.Background(), "ts(cpu)", step, start, end, fetch)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	query := &promapi.QueryRangeParams{Start: start, End: end, Step: step, Query: "ts(cpu)"}
+	result, apiErr := promapi.ConvertFromWavefront(response, query, 5*time.Minute)
+	if apiErr != nil {
+		t.Fatalf("ConvertFromWavefront: %v", apiErr)
+	}
+	if len(result) != 1 {
+		t.Fatalf("len(result) = %d; want 1", len(result))
+	}
+	if len(result[0].Values) == 0 || result[0].Values[0][0] != start {
+		t.Errorf("Values = %v; want the first entry to report a sample at Start=%v "+
+			"(the pre-Start margin BuildWavefrontQuery fetched must survive the cache)",
+			result[0].Values, start)
+	}
+}
+
+func TestCacheStitchesSeriesAcrossIntervals(t *testing.T) {
+	now := float64(time.Now().Unix())
+	boundary := time.Hour
+	start := now - 2*boundary.Seconds()
+	end := now - boundary.Seconds()/2
+
+	c := New(NewLRU(10), boundary)
+	fetch := func(ctx context.Context, fetchStart, fetchEnd float64) (*wavefront.QueryResponse, error) {
+		// Return one datapoint per aligned interval covered by this fetch,
+		// the way Wavefront would report a steadily-reporting series.
+		var points []wavefront.DataPoint
+		for _, interval := range AlignedIntervals(fetchStart, fetchEnd, boundary.Seconds()) {
+			points = append(points, wavefront.DataPoint{interval.Start, 1})
+		}
+		return &wavefront.QueryResponse{
+			TimeSeries: []wavefront.TimeSeries{
+				{Label: "cpu", Host: "h1", DataPoints: points},
+			},
+		}, nil
+	}
+
+	wantPoints := len(AlignedIntervals(start, end, boundary.Seconds()))
+
+	response, err := c.Get(context.Background(), "ts(cpu)", 60, start, end, fetch)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(response.TimeSeries) != 1 {
+		t.Fatalf("len(TimeSeries) = %d; want 1", len(response.TimeSeries))
+	}
+	if len(response.TimeSeries[0].DataPoints) != wantPoints {
+		t.Errorf("len(DataPoints) = %d; want %d (one per interval, merged into a single series)",
+			len(response.TimeSeries[0].DataPoints), wantPoints)
+	}
+}
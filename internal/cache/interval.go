@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"fmt"
+	"math"
+)
+
+// Interval is a half-open [Start, End) span of a requested query_range,
+// aligned to the Cache's boundary.
+type Interval struct {
+	Start float64
+	End   float64
+}
+
+// AlignedIntervals splits [start, end) into sub-intervals aligned to
+// boundary (e.g. the start of each hour), clipped to [start, end) at
+// either edge. boundary must be positive.
+func AlignedIntervals(start, end, boundary float64) []Interval {
+	var intervals []Interval
+	for t := math.Floor(start/boundary) * boundary; t < end; t += boundary {
+		ivStart := math.Max(t, start)
+		ivEnd := math.Min(t+boundary, end)
+		if ivEnd > ivStart {
+			intervals = append(intervals, Interval{Start: ivStart, End: ivEnd})
+		}
+	}
+	return intervals
+}
+
+// Key identifies the Wavefront response for a single aligned interval of
+// query at the given step.
+func Key(query string, step float64, intervalStart float64) string {
+	return fmt.Sprintf("%s|%v|%v", query, step, intervalStart)
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAlignedIntervals(t *testing.T) {
+	testCases := []struct {
+		name       string
+		start, end float64
+		boundary   float64
+		want       []Interval
+	}{
+		{
+			name:     "within a single boundary",
+			start:    100,
+			end:      200,
+			boundary: 3600,
+			want:     []Interval{{Start: 100, End: 200}},
+		},
+		{
+			name:     "spans two boundaries",
+			start:    3500,
+			end:      3700,
+			boundary: 3600,
+			want:     []Interval{{Start: 3500, End: 3600}, {Start: 3600, End: 3700}},
+		},
+		{
+			name:     "empty range",
+			start:    100,
+			end:      100,
+			boundary: 3600,
+			want:     nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := AlignedIntervals(tc.start, tc.end, tc.boundary)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("AlignedIntervals() = %+v; want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeyDistinguishesQueryStepAndInterval(t *testing.T) {
+	if Key("a", 1, 0) == Key("b", 1, 0) {
+		t.Error("different queries produced the same key")
+	}
+	if Key("a", 1, 0) == Key("a", 2, 0) {
+		t.Error("different steps produced the same key")
+	}
+	if Key("a", 1, 0) == Key("a", 1, 3600) {
+		t.Error("different interval starts produced the same key")
+	}
+}
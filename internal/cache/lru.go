@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+)
+
+// LRU is the default, in-memory Backend. It is safe for concurrent use.
+type LRU struct {
+	capacity int
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value *wavefront.QueryResponse
+}
+
+// NewLRU returns an LRU backend holding at most capacity entries, evicting
+// the least recently used one once full.
+func NewLRU(capacity int) *LRU {
+	return &LRU{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *LRU) Get(key string) (*wavefront.QueryResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+func (c *LRU) Set(key string, value *wavefront.QueryResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		c.ll.MoveToFront(elem)
+		elem.Value.(*lruEntry).value = value
+		return
+	}
+	elem := c.ll.PushFront(&lruEntry{key: key, value: value})
+	c.items[key] = elem
+	if c.capacity > 0 && c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+)
+
+func TestLRUGetSet(t *testing.T) {
+	l := NewLRU(2)
+	if _, ok := l.Get("missing"); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+	want := &wavefront.QueryResponse{Name: "a"}
+	l.Set("a", want)
+	got, ok := l.Get("a")
+	if !ok || got != want {
+		t.Fatalf("Get(\"a\") = %v, %v; want %v, true", got, ok, want)
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	l := NewLRU(2)
+	l.Set("a", &wavefront.QueryResponse{Name: "a"})
+	l.Set("b", &wavefront.QueryResponse{Name: "b"})
+	l.Get("a") // touch a so b becomes the least recently used
+	l.Set("c", &wavefront.QueryResponse{Name: "c"})
+
+	if _, ok := l.Get("b"); ok {
+		t.Error("expected b to have been evicted")
+	}
+	if _, ok := l.Get("a"); !ok {
+		t.Error("expected a to still be cached")
+	}
+	if _, ok := l.Get("c"); !ok {
+		t.Error("expected c to still be cached")
+	}
+}
@@ -0,0 +1,19 @@
+package cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	hitsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "wavefront_promql_proxy_cache_hits_total",
+		Help: "Number of cache-aligned query intervals served from cache.",
+	})
+
+	missesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "wavefront_promql_proxy_cache_misses_total",
+		Help: "Number of cache-aligned query intervals fetched from Wavefront.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(hitsTotal, missesTotal)
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+)
+
+// filterByRange returns a copy of response with each series' datapoints
+// restricted to [start, end), the slice of it that belongs to a single
+// cached interval.
+func filterByRange(response *wavefront.QueryResponse, start, end float64) *wavefront.QueryResponse {
+	result := &wavefront.QueryResponse{
+		TimeSeries: make([]wavefront.TimeSeries, len(response.TimeSeries)),
+		ErrType:    response.ErrType,
+		ErrMessage: response.ErrMessage,
+	}
+	for i, ts := range response.TimeSeries {
+		result.TimeSeries[i] = wavefront.TimeSeries{
+			Label: ts.Label,
+			Host:  ts.Host,
+			Tags:  ts.Tags,
+		}
+		for _, point := range ts.DataPoints {
+			if point[0] >= start && point[0] < end {
+				result.TimeSeries[i].DataPoints = append(result.TimeSeries[i].DataPoints, point)
+			}
+		}
+	}
+	return result
+}
+
+// mergeResponses stitches the per-interval pieces covering a request back
+// into a single response, concatenating each series' datapoints in the
+// order the intervals were given.
+func mergeResponses(pieces ...*wavefront.QueryResponse) *wavefront.QueryResponse {
+	result := &wavefront.QueryResponse{}
+	series := make(map[string]*wavefront.TimeSeries)
+	var order []string
+	for _, piece := range pieces {
+		if piece == nil {
+			continue
+		}
+		if piece.ErrType != "" && result.ErrType == "" {
+			result.ErrType = piece.ErrType
+			result.ErrMessage = piece.ErrMessage
+		}
+		for i := range piece.TimeSeries {
+			ts := &piece.TimeSeries[i]
+			key := seriesKey(ts)
+			existing, ok := series[key]
+			if !ok {
+				copied := &wavefront.TimeSeries{Label: ts.Label, Host: ts.Host, Tags: ts.Tags}
+				series[key] = copied
+				order = append(order, key)
+				existing = copied
+			}
+			existing.DataPoints = append(existing.DataPoints, ts.DataPoints...)
+		}
+	}
+	result.TimeSeries = make([]wavefront.TimeSeries, len(order))
+	for i, key := range order {
+		result.TimeSeries[i] = *series[key]
+	}
+	return result
+}
+
+// seriesKey identifies a TimeSeries by its label, host, and tags so that
+// the same series reported across several intervals can be merged back
+// together.
+func seriesKey(ts *wavefront.TimeSeries) string {
+	keys := make([]string, 0, len(ts.Tags))
+	for k := range ts.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	key := fmt.Sprintf("%s\x00%s", ts.Label, ts.Host)
+	for _, k := range keys {
+		key += fmt.Sprintf("\x00%s=%s", k, ts.Tags[k])
+	}
+	return key
+}
@@ -0,0 +1,221 @@
+package promapi
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+)
+
+// QueryRangeData is the "data" field of an apiResponse for
+// /api/v1/query_range.
+type QueryRangeData struct {
+	Result     []MatrixSeries `json:"result"`
+	ResultType string         `json:"resultType"`
+}
+
+// MatrixSeries is a single series within a QueryRangeData.
+type MatrixSeries struct {
+	Metric map[string]string `json:"metric"`
+	Values [][2]interface{}  `json:"values"`
+}
+
+// InstantQueryData is the "data" field of an apiResponse for
+// /api/v1/query.
+type InstantQueryData struct {
+	Result     []VectorSample `json:"result"`
+	ResultType string         `json:"resultType"`
+}
+
+// VectorSample is a single series within an InstantQueryData.
+type VectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  [2]interface{}    `json:"value"`
+}
+
+// ConvertFromWavefront converts a Wavefront query_range response into the
+// matrix result Prometheus' /api/v1/query_range returns. lookback bounds how
+// stale a Wavefront datapoint may be and still stand in for a requested
+// evaluation timestamp; it should match the -lookback flag.
+func ConvertFromWavefront(
+	response *wavefront.QueryResponse, query *QueryRangeParams, lookback time.Duration) ([]MatrixSeries, *APIError) {
+	if response.ErrType != "" {
+		return nil, NewBadDataError(response.ErrMessage)
+	}
+	result := make([]MatrixSeries, len(response.TimeSeries))
+	for i := range response.TimeSeries {
+		result[i].Metric = ExtractMetric(&response.TimeSeries[i])
+		result[i].Values = ExtractMatrixData(
+			response.TimeSeries[i].DataPoints, query, lookback)
+	}
+	SortMatrixSeriesInPlace(result)
+	return result, nil
+}
+
+// ExtractMetric builds a Prometheus label set from a single Wavefront
+// TimeSeries.
+func ExtractMetric(t *wavefront.TimeSeries) map[string]string {
+	result := make(map[string]string)
+	if t.Label != "" {
+		result["__name__"] = t.Label
+	}
+	if t.Host != "" {
+		// TODO: If there is a "instance" tag, this will get clobbered
+		result["instance"] = t.Host
+	}
+	for k, v := range t.Tags {
+		result[k] = v
+	}
+	return result
+}
+
+// LastDataPointAtOrBefore returns the last Wavefront datapoint at or
+// before at, the sample /api/v1/query reports for a series.
+func LastDataPointAtOrBefore(data []wavefront.DataPoint, at float64) ([2]interface{}, bool) {
+	for i := len(data) - 1; i >= 0; i-- {
+		if data[i][0] <= at {
+			return [2]interface{}{data[i][0], FloatToString(data[i][1])}, true
+		}
+	}
+	return [2]interface{}{}, false
+}
+
+func FloatToString(x float64) string {
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
+
+// ExtractMatrixData simulates the step functionality of promQL against
+// Wavefront's raw datapoints. For each requested evaluation timestamp
+// t = query.Start + i*query.Step, it reports a sample only if a Wavefront
+// datapoint falls within lookback (or query.Step, whichever is larger) of
+// t, omitting the timestamp entirely once the series has gone stale for
+// longer than that, the same staleness behavior Prometheus itself uses. If
+// the request's step is finer than Wavefront's own reporting granularity,
+// the value is linearly interpolated between the two surrounding
+// datapoints rather than just repeating the last one.
+func ExtractMatrixData(
+	data []wavefront.DataPoint, query *QueryRangeParams, lookback time.Duration) [][2]interface{} {
+	resultSize := int((query.End-query.Start)/query.Step) + 1
+	result := make([][2]interface{}, 0, resultSize)
+	if len(data) == 0 {
+		return result
+	}
+	maxGap := lookback.Seconds()
+	if query.Step > maxGap {
+		maxGap = query.Step
+	}
+	granularity := nativeGranularity(data)
+	index := 0
+	for i := 0; i < resultSize; i++ {
+		timestamp := query.Start + float64(i)*query.Step
+		for index+1 < len(data) && data[index+1][0] <= timestamp {
+			index++
+		}
+		timestampdiff := timestamp - data[index][0]
+		if timestampdiff < 0 || timestampdiff >= maxGap {
+			continue
+		}
+		if query.Step < granularity && index+1 < len(data) && data[index+1][0] > timestamp {
+			result = append(result, [2]interface{}{
+				timestamp, FloatToString(interpolate(data[index], data[index+1], timestamp))})
+		} else {
+			result = append(result, [2]interface{}{
+				timestamp, FloatToString(data[index][1])})
+		}
+	}
+	return result
+}
+
+// nativeGranularity estimates the interval at which Wavefront is actually
+// reporting datapoints, as the median gap between consecutive timestamps.
+// The median, rather than the mean, keeps an occasional dropped or
+// double-reported point from skewing the estimate.
+func nativeGranularity(data []wavefront.DataPoint) float64 {
+	if len(data) < 2 {
+		return 0
+	}
+	deltas := make([]float64, 0, len(data)-1)
+	for i := 1; i < len(data); i++ {
+		if delta := data[i][0] - data[i-1][0]; delta > 0 {
+			deltas = append(deltas, delta)
+		}
+	}
+	if len(deltas) == 0 {
+		return 0
+	}
+	sort.Float64s(deltas)
+	mid := len(deltas) / 2
+	if len(deltas)%2 == 0 {
+		return (deltas[mid-1] + deltas[mid]) / 2
+	}
+	return deltas[mid]
+}
+
+// interpolate linearly interpolates the value at t between the datapoints
+// before and after it.
+func interpolate(before, after wavefront.DataPoint, t float64) float64 {
+	if after[0] == before[0] {
+		return before[1]
+	}
+	frac := (t - before[0]) / (after[0] - before[0])
+	return before[1] + frac*(after[1]-before[1])
+}
+
+func SortMatrixSeriesInPlace(timeSeries []MatrixSeries) {
+	sorter := matrixSeriesSorter{timeSeries: timeSeries}
+	sorter.initialize()
+	sort.Sort(&sorter)
+}
+
+type matrixSeriesSorter struct {
+	timeSeries      []MatrixSeries
+	metricKeyValues [][]string
+}
+
+func (p *matrixSeriesSorter) initialize() {
+	p.metricKeyValues = make([][]string, len(p.timeSeries))
+	for i := range p.timeSeries {
+		p.metricKeyValues[i] = metricMapToSlice(p.timeSeries[i].Metric)
+	}
+}
+
+func (p *matrixSeriesSorter) Less(i, j int) bool {
+	return sliceLess(p.metricKeyValues[i], p.metricKeyValues[j])
+}
+
+func (p *matrixSeriesSorter) Swap(i, j int) {
+	p.metricKeyValues[i], p.metricKeyValues[j] = p.metricKeyValues[j], p.metricKeyValues[i]
+	p.timeSeries[i], p.timeSeries[j] = p.timeSeries[j], p.timeSeries[i]
+}
+
+func (p *matrixSeriesSorter) Len() int {
+	return len(p.timeSeries)
+}
+
+func metricMapToSlice(metric map[string]string) []string {
+	keys := make([]string, 0, len(metric))
+	for key := range metric {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]string, 0, 2*len(metric))
+	for _, key := range keys {
+		result = append(result, key, metric[key])
+	}
+	return result
+}
+
+func sliceLess(lhs, rhs []string) bool {
+	i := 0
+	for i < len(lhs) && i < len(rhs) {
+		if lhs[i] < rhs[i] {
+			return true
+		}
+		if lhs[i] > rhs[i] {
+			return false
+		}
+		i++
+	}
+	return len(lhs) < len(rhs)
+}
@@ -0,0 +1,105 @@
+package promapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+)
+
+func dp(timestamp, value float64) wavefront.DataPoint {
+	return wavefront.DataPoint{timestamp, value}
+}
+
+func TestExtractMatrixDataLastValueWithinStep(t *testing.T) {
+	data := []wavefront.DataPoint{dp(0, 1), dp(10, 2), dp(20, 3)}
+	query := &QueryRangeParams{Start: 0, End: 20, Step: 10}
+	got := ExtractMatrixData(data, query, 5*time.Minute)
+	want := [][2]interface{}{
+		{0.0, "1"}, {10.0, "2"}, {20.0, "3"},
+	}
+	assertMatrixValuesEqual(t, got, want)
+}
+
+func TestExtractMatrixDataOmitsStaleSamples(t *testing.T) {
+	// A single datapoint at t=0, then nothing until t=400: anything
+	// requested more than lookback seconds after it should be omitted
+	// rather than repeating the stale value forever.
+	data := []wavefront.DataPoint{dp(0, 1), dp(400, 2)}
+	query := &QueryRangeParams{Start: 0, End: 400, Step: 100}
+	got := ExtractMatrixData(data, query, 1*time.Minute)
+	want := [][2]interface{}{
+		{0.0, "1"}, {400.0, "2"},
+	}
+	assertMatrixValuesEqual(t, got, want)
+}
+
+func TestExtractMatrixDataInterpolatesWhenStepFinerThanGranularity(t *testing.T) {
+	// Wavefront is reporting every 10s, but the request asks for a 5s
+	// step; the midpoint should be interpolated rather than repeating
+	// the previous value.
+	data := []wavefront.DataPoint{dp(0, 0), dp(10, 10), dp(20, 20)}
+	query := &QueryRangeParams{Start: 0, End: 20, Step: 5}
+	got := ExtractMatrixData(data, query, 5*time.Minute)
+	want := [][2]interface{}{
+		{0.0, "0"}, {5.0, "5"}, {10.0, "10"}, {15.0, "15"}, {20.0, "20"},
+	}
+	assertMatrixValuesEqual(t, got, want)
+}
+
+func TestExtractMatrixDataNoDataPoints(t *testing.T) {
+	query := &QueryRangeParams{Start: 0, End: 20, Step: 10}
+	got := ExtractMatrixData(nil, query, 5*time.Minute)
+	if len(got) != 0 {
+		t.Errorf("got %v; want empty", got)
+	}
+}
+
+func TestExtractMatrixDataBeforeFirstDataPoint(t *testing.T) {
+	data := []wavefront.DataPoint{dp(100, 1)}
+	query := &QueryRangeParams{Start: 0, End: 100, Step: 50}
+	got := ExtractMatrixData(data, query, 5*time.Minute)
+	want := [][2]interface{}{
+		{100.0, "1"},
+	}
+	assertMatrixValuesEqual(t, got, want)
+}
+
+func TestNativeGranularityMedianOfDeltas(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []wavefront.DataPoint
+		want float64
+	}{
+		{name: "too few points", data: []wavefront.DataPoint{dp(0, 0)}, want: 0},
+		{
+			name: "regular spacing",
+			data: []wavefront.DataPoint{dp(0, 0), dp(10, 0), dp(20, 0), dp(30, 0)},
+			want: 10,
+		},
+		{
+			name: "robust to a single gap",
+			data: []wavefront.DataPoint{dp(0, 0), dp(10, 0), dp(20, 0), dp(100, 0), dp(110, 0)},
+			want: 10,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := nativeGranularity(tc.data); got != tc.want {
+				t.Errorf("nativeGranularity() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func assertMatrixValuesEqual(t *testing.T, got, want [][2]interface{}) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+	for i := range got {
+		if got[i][0] != want[i][0] || got[i][1] != want[i][1] {
+			t.Errorf("value[%d] = %v; want %v", i, got[i], want[i])
+		}
+	}
+}
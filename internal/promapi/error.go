@@ -0,0 +1,34 @@
+// Package promapi implements request parsing and response formatting for
+// the Prometheus HTTP API, plus the conversion of Wavefront query
+// responses into Prometheus result types.
+package promapi
+
+import "fmt"
+
+// APIError is an error that carries a Prometheus errorType
+// (https://prometheus.io/docs/prometheus/latest/querying/api/#format-overview)
+// alongside the message so WriteError can pick the right HTTP status code.
+type APIError struct {
+	ErrorType string
+	Err       string
+}
+
+func (e *APIError) Error() string {
+	return e.Err
+}
+
+// NewBadDataError creates an APIError with errorType "bad_data", the type
+// Prometheus uses for malformed request parameters.
+func NewBadDataError(format string, args ...interface{}) *APIError {
+	return &APIError{ErrorType: "bad_data", Err: fmt.Sprintf(format, args...)}
+}
+
+// NewTimeoutError creates an APIError with errorType "timeout".
+func NewTimeoutError(format string, args ...interface{}) *APIError {
+	return &APIError{ErrorType: "timeout", Err: fmt.Sprintf(format, args...)}
+}
+
+// NewCanceledError creates an APIError with errorType "canceled".
+func NewCanceledError(format string, args ...interface{}) *APIError {
+	return &APIError{ErrorType: "canceled", Err: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,161 @@
+package promapi
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxResolutionPoints bounds the number of samples a single query_range
+// call can ask for, mirroring the limit Prometheus itself enforces so that
+// a typo'd step doesn't turn into a query asking Wavefront for millions of
+// points.
+const maxResolutionPoints = 11000
+
+// QueryRangeParams is the parsed form of a /api/v1/query_range request.
+type QueryRangeParams struct {
+	Start float64
+	End   float64
+	Step  float64
+	Query string
+}
+
+// InstantQueryParams is the parsed form of a /api/v1/query request.
+type InstantQueryParams struct {
+	Query string
+	Time  float64
+}
+
+// SeriesParams is the parsed form of a /api/v1/series request.
+type SeriesParams struct {
+	Matchers []string
+	Start    float64
+	End      float64
+}
+
+// ParseQueryRange parses the start, end, step, and query parameters of a
+// /api/v1/query_range request.
+func ParseQueryRange(r *http.Request) (*QueryRangeParams, *APIError) {
+	start, err := parseTimestamp(r.Form.Get("start"))
+	if err != nil {
+		return nil, NewBadDataError(
+			"invalid parameter 'start': cannot parse \"%s\" to a valid timestamp", r.Form.Get("start"))
+	}
+	end, err := parseTimestamp(r.Form.Get("end"))
+	if err != nil {
+		return nil, NewBadDataError(
+			"invalid parameter 'end': cannot parse \"%s\" to a valid timestamp", r.Form.Get("end"))
+	}
+	step, err := parseStep(r.Form.Get("step"))
+	if err != nil {
+		return nil, NewBadDataError(
+			"invalid parameter 'step': cannot parse \"%s\" to a valid duration", r.Form.Get("step"))
+	}
+	if step <= 0.0 {
+		return nil, NewBadDataError(
+			"zero or negative query resolution step widths are not accepted. Try a positive integer")
+	}
+	if end < start {
+		return nil, NewBadDataError("end timestamp must not be before start time")
+	}
+	if (end-start)/step > maxResolutionPoints {
+		return nil, NewBadDataError(
+			"exceeded maximum resolution of %d points per timeseries. Try decreasing the query resolution (?step=XX)",
+			maxResolutionPoints)
+	}
+	return &QueryRangeParams{
+		Start: start,
+		End:   end,
+		Step:  step,
+		Query: r.Form.Get("query"),
+	}, nil
+}
+
+// ParseInstantQuery parses the query and optional time parameters of a
+// /api/v1/query request. time defaults to now.
+func ParseInstantQuery(r *http.Request) (*InstantQueryParams, *APIError) {
+	query := r.Form.Get("query")
+	if query == "" {
+		return nil, NewBadDataError("invalid parameter 'query': query is empty")
+	}
+	at := float64(time.Now().UnixNano()) / float64(time.Second)
+	if timeStr := r.Form.Get("time"); timeStr != "" {
+		t, err := parseTimestamp(timeStr)
+		if err != nil {
+			return nil, NewBadDataError(
+				"invalid parameter 'time': cannot parse \"%s\" to a valid timestamp", timeStr)
+		}
+		at = t
+	}
+	return &InstantQueryParams{Query: query, Time: at}, nil
+}
+
+// ParseSeries parses the match[], start, and end parameters of a
+// /api/v1/series request.
+func ParseSeries(r *http.Request, defaultRange time.Duration) (*SeriesParams, *APIError) {
+	matchers := r.Form["match[]"]
+	if len(matchers) == 0 {
+		return nil, NewBadDataError("no match[] parameter provided")
+	}
+	start, end, err := ParseStartEnd(r, defaultRange)
+	if err != nil {
+		return nil, err
+	}
+	return &SeriesParams{Matchers: matchers, Start: start, End: end}, nil
+}
+
+// ParseStartEnd reads the optional start/end query parameters, defaulting
+// end to now and start to end+defaultRange (defaultRange is expected to be
+// negative, e.g. -time.Hour). It is shared by /api/v1/series,
+// /api/v1/labels, /api/v1/label/<name>/values, and /api/v1/metadata.
+func ParseStartEnd(r *http.Request, defaultRange time.Duration) (start, end float64, apiErr *APIError) {
+	end = float64(time.Now().Unix())
+	if endStr := r.Form.Get("end"); endStr != "" {
+		parsed, err := parseTimestamp(endStr)
+		if err != nil {
+			return 0, 0, NewBadDataError(
+				"invalid parameter 'end': cannot parse \"%s\" to a valid timestamp", endStr)
+		}
+		end = parsed
+	}
+	start = end + float64(defaultRange)/float64(time.Second)
+	if startStr := r.Form.Get("start"); startStr != "" {
+		parsed, err := parseTimestamp(startStr)
+		if err != nil {
+			return 0, 0, NewBadDataError(
+				"invalid parameter 'start': cannot parse \"%s\" to a valid timestamp", startStr)
+		}
+		start = parsed
+	}
+	if end < start {
+		return 0, 0, NewBadDataError("end timestamp must not be before start time")
+	}
+	return start, end, nil
+}
+
+// parseTimestamp parses a Prometheus API timestamp, which is either a
+// Unix timestamp with optional fractional seconds, or an RFC3339 string.
+func parseTimestamp(str string) (float64, error) {
+	if t, err := strconv.ParseFloat(str, 64); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return 0, err
+	}
+	return float64(t.UnixNano()) / float64(time.Second), nil
+}
+
+// parseStep parses a Prometheus API step, which is either a number of
+// seconds with optional fractional seconds, or a Go duration string such
+// as "15s" or "1m".
+func parseStep(str string) (float64, error) {
+	if step, err := strconv.ParseFloat(str, 64); err == nil {
+		return step, nil
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, err
+	}
+	return float64(d) / float64(time.Second), nil
+}
@@ -0,0 +1,186 @@
+package promapi
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func formRequest(values url.Values) *http.Request {
+	r := &http.Request{Form: values}
+	return r
+}
+
+func TestParseQueryRange(t *testing.T) {
+	type testCase struct {
+		name        string
+		form        url.Values
+		wantErr     bool
+		wantErrType string
+		want        *QueryRangeParams
+	}
+	testCases := []testCase{
+		{
+			name: "valid",
+			form: url.Values{
+				"start": {"1000"},
+				"end":   {"2000"},
+				"step":  {"15"},
+				"query": {"ts(some.metric)"},
+			},
+			want: &QueryRangeParams{Start: 1000, End: 2000, Step: 15, Query: "ts(some.metric)"},
+		},
+		{
+			name: "valid RFC3339 timestamps",
+			form: url.Values{
+				"start": {"1970-01-01T00:16:40Z"},
+				"end":   {"1970-01-01T00:33:20Z"},
+				"step":  {"15s"},
+				"query": {"ts(some.metric)"},
+			},
+			want: &QueryRangeParams{Start: 1000, End: 2000, Step: 15, Query: "ts(some.metric)"},
+		},
+		{
+			name: "step as Go duration",
+			form: url.Values{
+				"start": {"1000"},
+				"end":   {"2000"},
+				"step":  {"1m"},
+				"query": {"ts(some.metric)"},
+			},
+			want: &QueryRangeParams{Start: 1000, End: 2000, Step: 60, Query: "ts(some.metric)"},
+		},
+		{
+			name:        "malformed start",
+			form:        url.Values{"start": {"not-a-time"}, "end": {"2000"}, "step": {"15"}},
+			wantErr:     true,
+			wantErrType: "bad_data",
+		},
+		{
+			name:        "malformed end",
+			form:        url.Values{"start": {"1000"}, "end": {"not-a-time"}, "step": {"15"}},
+			wantErr:     true,
+			wantErrType: "bad_data",
+		},
+		{
+			name:        "malformed step",
+			form:        url.Values{"start": {"1000"}, "end": {"2000"}, "step": {"not-a-duration"}},
+			wantErr:     true,
+			wantErrType: "bad_data",
+		},
+		{
+			name:        "zero step",
+			form:        url.Values{"start": {"1000"}, "end": {"2000"}, "step": {"0"}},
+			wantErr:     true,
+			wantErrType: "bad_data",
+		},
+		{
+			name:        "negative step",
+			form:        url.Values{"start": {"1000"}, "end": {"2000"}, "step": {"-15"}},
+			wantErr:     true,
+			wantErrType: "bad_data",
+		},
+		{
+			name:        "end before start",
+			form:        url.Values{"start": {"2000"}, "end": {"1000"}, "step": {"15"}},
+			wantErr:     true,
+			wantErrType: "bad_data",
+		},
+		{
+			name:        "missing query is allowed, missing timestamps are not",
+			form:        url.Values{"start": {""}, "end": {"2000"}, "step": {"15"}},
+			wantErr:     true,
+			wantErrType: "bad_data",
+		},
+		{
+			name: "oversize range",
+			form: url.Values{
+				"start": {"0"},
+				"end":   {"1000000"},
+				"step":  {"1"},
+				"query": {"ts(some.metric)"},
+			},
+			wantErr:     true,
+			wantErrType: "bad_data",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseQueryRange(formRequest(tc.form))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				if err.ErrorType != tc.wantErrType {
+					t.Errorf("errorType = %q; want %q", err.ErrorType, tc.wantErrType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != *tc.want {
+				t.Errorf("ParseQueryRange() = %+v; want %+v", *got, *tc.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryRangeMissingQueryIsEmptyString(t *testing.T) {
+	got, err := ParseQueryRange(formRequest(url.Values{
+		"start": {"1000"},
+		"end":   {"2000"},
+		"step":  {"15"},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Query != "" {
+		t.Errorf("Query = %q; want empty string", got.Query)
+	}
+}
+
+func TestParseInstantQuery(t *testing.T) {
+	_, err := ParseInstantQuery(formRequest(url.Values{}))
+	if err == nil || err.ErrorType != "bad_data" {
+		t.Fatalf("expected bad_data error for missing query, got %v", err)
+	}
+
+	got, err := ParseInstantQuery(formRequest(url.Values{
+		"query": {"ts(some.metric)"},
+		"time":  {"1000"},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Query != "ts(some.metric)" || got.Time != 1000 {
+		t.Errorf("ParseInstantQuery() = %+v", got)
+	}
+
+	_, err = ParseInstantQuery(formRequest(url.Values{
+		"query": {"ts(some.metric)"},
+		"time":  {"garbage"},
+	}))
+	if err == nil || err.ErrorType != "bad_data" {
+		t.Fatalf("expected bad_data error for malformed time, got %v", err)
+	}
+}
+
+func TestParseSeries(t *testing.T) {
+	_, err := ParseSeries(formRequest(url.Values{}), -3600e9)
+	if err == nil || err.ErrorType != "bad_data" {
+		t.Fatalf("expected bad_data error for missing match[], got %v", err)
+	}
+
+	got, err := ParseSeries(formRequest(url.Values{
+		"match[]": {`a_metric{job="api"}`, "b_metric"},
+		"start":   {"1000"},
+		"end":     {"2000"},
+	}), -3600e9)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Matchers) != 2 || got.Start != 1000 || got.End != 2000 {
+		t.Errorf("ParseSeries() = %+v", got)
+	}
+}
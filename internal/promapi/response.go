@@ -0,0 +1,54 @@
+package promapi
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// apiResponse is the envelope every endpoint in the Prometheus HTTP API
+// wraps its payload in.
+type apiResponse struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// WriteJSON writes a successful Prometheus API response with data as the
+// "data" field.
+func WriteJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.Encode(&apiResponse{Status: "success", Data: data})
+}
+
+// WriteError writes a failed Prometheus API response. If err is an
+// *APIError, its ErrorType and HTTP status are preserved; otherwise err is
+// reported as an internal error.
+func WriteError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		apiErr = &APIError{ErrorType: "internal", Err: err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForErrorType(apiErr.ErrorType))
+	encoder := json.NewEncoder(w)
+	encoder.Encode(&apiResponse{
+		Status:    "error",
+		ErrorType: apiErr.ErrorType,
+		Error:     apiErr.Err,
+	})
+}
+
+func statusCodeForErrorType(errorType string) int {
+	switch errorType {
+	case "bad_data":
+		return http.StatusBadRequest
+	case "timeout":
+		return http.StatusServiceUnavailable
+	case "canceled":
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
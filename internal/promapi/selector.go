@@ -0,0 +1,176 @@
+package promapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// matcherOp is the comparison a single label matcher within a PromQL
+// vector selector applies, e.g. the "=~" in `job=~"api.*"`.
+type matcherOp string
+
+const (
+	opEqual        matcherOp = "="
+	opNotEqual     matcherOp = "!="
+	opRegexMatch   matcherOp = "=~"
+	opRegexNoMatch matcherOp = "!~"
+)
+
+// labelMatcher is a single `label<op>"value"` term within a vector
+// selector.
+type labelMatcher struct {
+	Label string
+	Op    matcherOp
+	Value string
+}
+
+// Selector is a parsed PromQL vector selector such as
+// `node_cpu_seconds_total{job="api",mode!="idle"}`, the syntax real
+// Prometheus clients (Grafana, promtool, alertmanager) send as match[]
+// parameters to /api/v1/series.
+type Selector struct {
+	MetricName string
+	Matchers   []labelMatcher
+}
+
+// ParseSelector parses a single PromQL vector selector of the form
+// `metric_name{label="value",...}`, `metric_name`, or `{label="value",...}`.
+// It supports the =, !=, =~, and !~ matcher operators but not the full
+// PromQL grammar (offsets, ranges, functions, and so on have no meaning
+// for a match[] selector).
+func ParseSelector(s string) (*Selector, error) {
+	s = strings.TrimSpace(s)
+	metricName := s
+	braceStart := strings.IndexByte(s, '{')
+	var matcherBody string
+	if braceStart >= 0 {
+		if !strings.HasSuffix(s, "}") {
+			return nil, fmt.Errorf("selector %q: missing closing '}'", s)
+		}
+		metricName = strings.TrimSpace(s[:braceStart])
+		matcherBody = s[braceStart+1 : len(s)-1]
+	}
+	sel := &Selector{MetricName: metricName}
+	if strings.TrimSpace(matcherBody) != "" {
+		matchers, err := parseLabelMatchers(matcherBody)
+		if err != nil {
+			return nil, fmt.Errorf("selector %q: %v", s, err)
+		}
+		sel.Matchers = matchers
+	}
+	if sel.MetricName == "" && len(sel.Matchers) == 0 {
+		return nil, fmt.Errorf("selector %q: empty selector", s)
+	}
+	return sel, nil
+}
+
+// parseLabelMatchers splits the comma-separated `label<op>"value"` terms
+// inside the braces of a vector selector. Commas inside quoted values are
+// not treated as separators.
+func parseLabelMatchers(body string) ([]labelMatcher, error) {
+	var matchers []labelMatcher
+	for _, term := range splitOutsideQuotes(body, ',') {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		m, err := parseLabelMatcher(term)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+// matcherOps is checked longest-first so "!=" isn't mistaken for a
+// prefix of some other operator.
+var matcherOps = []matcherOp{opRegexMatch, opRegexNoMatch, opNotEqual, opEqual}
+
+func parseLabelMatcher(term string) (labelMatcher, error) {
+	// The operator always precedes the quoted value, so only look for it
+	// there: searching the whole term would let an operator-like
+	// substring inside the value itself (e.g. `tag!="a=~b"`) be mistaken
+	// for the real operator.
+	searchRegion := term
+	if quoteIdx := strings.IndexByte(term, '"'); quoteIdx >= 0 {
+		searchRegion = term[:quoteIdx]
+	}
+	for _, op := range matcherOps {
+		idx := strings.Index(searchRegion, string(op))
+		if idx < 0 {
+			continue
+		}
+		label := strings.TrimSpace(term[:idx])
+		value := strings.TrimSpace(term[idx+len(op):])
+		value = strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+		if label == "" {
+			return labelMatcher{}, fmt.Errorf("missing label name in %q", term)
+		}
+		return labelMatcher{Label: label, Op: op, Value: value}, nil
+	}
+	return labelMatcher{}, fmt.Errorf("no matcher operator found in %q", term)
+}
+
+// splitOutsideQuotes splits s on sep, ignoring any sep that falls inside a
+// double-quoted substring.
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case sep:
+			if !inQuotes {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[start:])
+	return parts
+}
+
+// ToWavefrontQuery translates the selector into the ts() query Wavefront
+// must be asked in order to find the matching series. The PromQL
+// `__name__` label, if present among the matchers, takes the place of a
+// bare metric name.
+func (s *Selector) ToWavefrontQuery() (string, error) {
+	metricName := s.MetricName
+	var conditions []string
+	for _, m := range s.Matchers {
+		if m.Label == "__name__" {
+			if m.Op != opEqual {
+				return "", fmt.Errorf("__name__ only supports the = operator, got %q", m.Op)
+			}
+			metricName = m.Value
+			continue
+		}
+		conditions = append(conditions, wavefrontCondition(m))
+	}
+	if metricName == "" {
+		metricName = "*"
+	}
+	if len(conditions) == 0 {
+		return fmt.Sprintf("ts(%s)", metricName), nil
+	}
+	return fmt.Sprintf("ts(%s, %s)", metricName, strings.Join(conditions, " and ")), nil
+}
+
+// wavefrontCondition renders a single label matcher as a Wavefront point
+// tag filter. =~ and !~ are translated to tagFilter(), the WQL function
+// that matches a tag's value against a regular expression.
+func wavefrontCondition(m labelMatcher) string {
+	switch m.Op {
+	case opNotEqual:
+		return fmt.Sprintf("%s!=%q", m.Label, m.Value)
+	case opRegexMatch:
+		return fmt.Sprintf("tagFilter(%s, %q)", m.Label, m.Value)
+	case opRegexNoMatch:
+		return fmt.Sprintf("not tagFilter(%s, %q)", m.Label, m.Value)
+	default:
+		return fmt.Sprintf("%s=%q", m.Label, m.Value)
+	}
+}
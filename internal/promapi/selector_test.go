@@ -0,0 +1,89 @@
+package promapi
+
+import "testing"
+
+func TestParseSelectorAndToWavefrontQuery(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "bare metric name",
+			input: "node_cpu_seconds_total",
+			want:  "ts(node_cpu_seconds_total)",
+		},
+		{
+			name:  "metric with equality matcher",
+			input: `up{job="api"}`,
+			want:  `ts(up, job="api")`,
+		},
+		{
+			name:  "multiple matchers joined with and",
+			input: `up{job="api",instance="10.0.0.1:9100"}`,
+			want:  `ts(up, job="api" and instance="10.0.0.1:9100")`,
+		},
+		{
+			name:  "not-equal matcher",
+			input: `up{job!="api"}`,
+			want:  `ts(up, job!="api")`,
+		},
+		{
+			name:  "regex matchers",
+			input: `up{job=~"api.*",mode!~"idle"}`,
+			want:  `ts(up, tagFilter(job, "api.*") and not tagFilter(mode, "idle"))`,
+		},
+		{
+			name:  "__name__ matcher supplies the metric name",
+			input: `{__name__="node_cpu_seconds_total",job="api"}`,
+			want:  `ts(node_cpu_seconds_total, job="api")`,
+		},
+		{
+			name:  "operator-like substring inside a quoted value is not mistaken for the real operator",
+			input: `job{tag!="a=~b"}`,
+			want:  `ts(job, tag!="a=~b")`,
+		},
+		{
+			name:    "missing closing brace",
+			input:   `up{job="api"`,
+			wantErr: true,
+		},
+		{
+			name:    "empty selector",
+			input:   "",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sel, err := ParseSelector(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParseSelector(%q) = nil error, want error", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSelector(%q) unexpected error: %v", tc.input, err)
+			}
+			got, err := sel.ToWavefrontQuery()
+			if err != nil {
+				t.Fatalf("ToWavefrontQuery() unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ToWavefrontQuery() = %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectorNameOnlyOperatorRestriction(t *testing.T) {
+	sel, err := ParseSelector(`{__name__=~"node_.*"}`)
+	if err != nil {
+		t.Fatalf("ParseSelector() unexpected error: %v", err)
+	}
+	if _, err := sel.ToWavefrontQuery(); err == nil {
+		t.Fatalf("ToWavefrontQuery() = nil error, want error for __name__ with =~")
+	}
+}
@@ -0,0 +1,76 @@
+package promapi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+)
+
+// WavefrontQuery is the Wavefront equivalent of a PromQL query_range or
+// query request.
+type WavefrontQuery struct {
+	Q string
+	S string
+	E string
+	G string
+}
+
+// BuildWavefrontQuery converts a PromQL [start, end] window into the
+// Wavefront query Wavefront must be asked in order to answer it, adjusting
+// for the clock skew between this proxy's Wavefront server and real time.
+func BuildWavefrontQuery(query string, start, end float64, skew time.Duration) *WavefrontQuery {
+	skewSeconds := float64(skew) / float64(time.Second)
+
+	// We set the wavefront start time to be 15s before the promQL start time.
+	// We do this because otherwise, the first Wavefront data point may be
+	// after start time, and we won't get the correct value for start time.
+	// This isn't perfect as there is no guarantee that going 15s back is
+	// sufficient.
+	s := strconv.FormatInt(int64((start-15.0-skewSeconds)*1000), 10)
+
+	// In promQL, end time is inclusive, but in Wavefront it is exclusive.
+	// In wavefront times have to be at 1000ms less than end time.
+	e := strconv.FormatInt(int64((end+1.0-skewSeconds)*1000), 10)
+
+	// Here we set g=s to get a step of one second from wavefront. Later
+	// we will apply the step parameter from promQL when converting the
+	// response back to promQL.
+	return &WavefrontQuery{
+		Q: query,
+		S: s,
+		E: e,
+		G: "s",
+	}
+}
+
+// SkewLater shifts every datapoint timestamp in response forward by skew,
+// undoing the backward shift BuildWavefrontQuery applied so that the
+// timestamps in the final Prometheus response line up with wall clock
+// time rather than Wavefront's skewed clock.
+func SkewLater(response *wavefront.QueryResponse, skew time.Duration) *wavefront.QueryResponse {
+	skewSeconds := float64(skew) / float64(time.Second)
+	for i := range response.TimeSeries {
+		for j := range response.TimeSeries[i].DataPoints {
+			response.TimeSeries[i].DataPoints[j][0] += skewSeconds
+		}
+	}
+	return response
+}
+
+// SendToWavefront executes query against client and applies SkewLater to
+// the response. This is the one place every transport this proxy exposes
+// (HTTP, gRPC) funnels through to reach Wavefront.
+func SendToWavefront(client *wavefront.Client, query *WavefrontQuery, skew time.Duration) (
+	*wavefront.QueryResponse, error) {
+	qp := wavefront.NewQueryParams(query.Q)
+	qp.StartTime = query.S
+	qp.EndTime = query.E
+	qp.Granularity = query.G
+	q := client.NewQuery(qp)
+	response, err := q.Execute()
+	if err != nil {
+		return nil, err
+	}
+	return SkewLater(response, skew), nil
+}
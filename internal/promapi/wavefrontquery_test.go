@@ -0,0 +1,75 @@
+package promapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api"
+)
+
+func TestBuildWavefrontQuery(t *testing.T) {
+	testCases := []struct {
+		name       string
+		start, end float64
+		skew       time.Duration
+		wantS      string
+		wantE      string
+	}{
+		{
+			name:  "no skew",
+			start: 1000,
+			end:   2000,
+			skew:  0,
+			wantS: "985000",
+			wantE: "2001000",
+		},
+		{
+			name:  "positive skew shifts the wavefront window earlier",
+			start: 1000,
+			end:   2000,
+			skew:  10 * time.Second,
+			wantS: "975000",
+			wantE: "1991000",
+		},
+		{
+			name:  "negative skew shifts the wavefront window later",
+			start: 1000,
+			end:   2000,
+			skew:  -10 * time.Second,
+			wantS: "995000",
+			wantE: "2011000",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := BuildWavefrontQuery("ts(some.metric)", tc.start, tc.end, tc.skew)
+			if got.S != tc.wantS {
+				t.Errorf("S = %q; want %q", got.S, tc.wantS)
+			}
+			if got.E != tc.wantE {
+				t.Errorf("E = %q; want %q", got.E, tc.wantE)
+			}
+			if got.G != "s" {
+				t.Errorf("G = %q; want \"s\"", got.G)
+			}
+			if got.Q != "ts(some.metric)" {
+				t.Errorf("Q = %q; want \"ts(some.metric)\"", got.Q)
+			}
+		})
+	}
+}
+
+func TestSkewLater(t *testing.T) {
+	response := &wavefront.QueryResponse{
+		TimeSeries: []wavefront.TimeSeries{
+			{DataPoints: []wavefront.DataPoint{{1000, 1.5}, {1015, 2.5}}},
+		},
+	}
+	SkewLater(response, 10*time.Second)
+	if got := response.TimeSeries[0].DataPoints[0][0]; got != 1010 {
+		t.Errorf("DataPoints[0][0] = %v; want 1010", got)
+	}
+	if got := response.TimeSeries[0].DataPoints[1][0]; got != 1025 {
+		t.Errorf("DataPoints[1][0] = %v; want 1025", got)
+	}
+}
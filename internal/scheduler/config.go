@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the YAML configuration for a Scheduler.
+type Config struct {
+	// TenantHeader is the HTTP header the scheduler reads the tenant ID
+	// from. Defaults to "X-Scope-OrgID".
+	TenantHeader string `yaml:"tenant_header"`
+
+	// MaxInFlightPerTenant caps the number of requests a single tenant may
+	// have in flight at once. Zero means unlimited.
+	MaxInFlightPerTenant int `yaml:"max_inflight_per_tenant"`
+
+	// Rules classifies incoming requests into priority classes. Rules are
+	// evaluated in order; the first one whose Regex and duration bounds
+	// match wins. A request that matches no rule gets PriorityClass{}
+	// (priority 0, no reserved slots, no timeout).
+	Rules []RuleConfig `yaml:"rules"`
+}
+
+// RuleConfig is a single entry in Config.Rules.
+type RuleConfig struct {
+	// Regex is matched against the query string. An empty regex matches
+	// every query.
+	Regex string `yaml:"regex"`
+
+	// MinDuration and MaxDuration bound the [start, end] range of the
+	// query this rule applies to. MaxDuration of zero means unbounded.
+	MinDuration time.Duration `yaml:"min_duration"`
+	MaxDuration time.Duration `yaml:"max_duration"`
+
+	// Priority is the priority class this rule assigns. Higher values are
+	// higher priority.
+	Priority int `yaml:"priority"`
+
+	// ReservedSlots is the number of worker slots reserved exclusively for
+	// requests of this priority or higher. It is either an absolute
+	// integer ("5") or a percentage of total capacity ("25%").
+	ReservedSlots string `yaml:"reserved_slots"`
+
+	// Timeout is how long a request of this priority may wait in queue
+	// before being rejected. Zero means no timeout.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// LoadConfig reads and parses a Scheduler YAML config file from path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+	return &config, nil
+}
+
+// rule is a compiled RuleConfig.
+type rule struct {
+	regex         *regexp.Regexp
+	minDuration   time.Duration
+	maxDuration   time.Duration
+	priority      int
+	reservedSlots string
+	timeout       time.Duration
+}
+
+func compileRules(configs []RuleConfig) ([]rule, error) {
+	rules := make([]rule, len(configs))
+	for i, c := range configs {
+		re, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: invalid regex %q: %v", i, c.Regex, err)
+		}
+		rules[i] = rule{
+			regex:         re,
+			minDuration:   c.MinDuration,
+			maxDuration:   c.MaxDuration,
+			priority:      c.Priority,
+			reservedSlots: c.ReservedSlots,
+			timeout:       c.Timeout,
+		}
+	}
+	return rules, nil
+}
+
+// reservedSlots resolves a ReservedSlots string ("5" or "25%") against a
+// total capacity.
+func resolveReservedSlots(spec string, total int) (int, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return 0, nil
+	}
+	if strings.HasSuffix(spec, "%") {
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(spec, "%"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid reserved_slots %q: %v", spec, err)
+		}
+		return int(pct / 100 * float64(total)), nil
+	}
+	slots, err := strconv.Atoi(spec)
+	if err != nil {
+		return 0, fmt.Errorf("invalid reserved_slots %q: %v", spec, err)
+	}
+	return slots, nil
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import "testing"
+
+func TestResolveReservedSlots(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    string
+		total   int
+		want    int
+		wantErr bool
+	}{
+		{name: "empty", spec: "", total: 100, want: 0},
+		{name: "absolute", spec: "5", total: 100, want: 5},
+		{name: "percentage", spec: "25%", total: 100, want: 25},
+		{name: "percentage rounds down", spec: "1%", total: 10, want: 0},
+		{name: "malformed absolute", spec: "five", total: 100, wantErr: true},
+		{name: "malformed percentage", spec: "five%", total: 100, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveReservedSlots(tc.spec, tc.total)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("resolveReservedSlots(%q, %d) = %d; want %d", tc.spec, tc.total, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompileRulesInvalidRegex(t *testing.T) {
+	_, err := compileRules([]RuleConfig{{Regex: "("}})
+	if err == nil {
+		t.Fatal("expected error for invalid regex")
+	}
+}
@@ -0,0 +1,25 @@
+package scheduler
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	queueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "wavefront_promql_proxy_scheduler_queue_depth",
+		Help: "Number of requests currently waiting for a worker slot.",
+	}, []string{"tenant", "priority"})
+
+	waitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "wavefront_promql_proxy_scheduler_wait_seconds",
+		Help:    "Time requests spent waiting for a worker slot.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"tenant", "priority"})
+
+	rejectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "wavefront_promql_proxy_scheduler_rejections_total",
+		Help: "Number of requests rejected by the scheduler, by reason.",
+	}, []string{"tenant", "priority", "reason"})
+)
+
+func init() {
+	prometheus.MustRegister(queueDepth, waitSeconds, rejectionsTotal)
+}
@@ -0,0 +1,207 @@
+// Package scheduler admits incoming queries onto a bounded pool of worker
+// slots, enforcing a per-tenant concurrency limit and reserving slots for
+// higher priority queries so that a burst of expensive dashboard queries
+// cannot starve cheap alerting queries.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// SchedulingError is returned by Schedule when a request could not be
+// admitted. Reason is either "timeout" (the request's queue timeout
+// elapsed) or "canceled" (the caller's context was canceled).
+type SchedulingError struct {
+	Reason string
+	Err    string
+}
+
+func (e *SchedulingError) Error() string {
+	return e.Err
+}
+
+// Scheduler gates concurrent queries by tenant and priority.
+type Scheduler struct {
+	tenantHeader         string
+	maxInFlightPerTenant int64
+	rules                []rule
+	tiers                []tier
+	global               *semaphore.Weighted
+
+	mu         sync.Mutex
+	tenantSems map[string]*semaphore.Weighted
+}
+
+// tier is a priority class's reserved-capacity gate: any request whose
+// priority is lower than tier.priority must acquire tier.sem before it may
+// acquire the global semaphore, which keeps sem.Weighted's capacity of
+// reserved slots free for priority>=tier.priority requests.
+type tier struct {
+	priority int
+	sem      *semaphore.Weighted
+}
+
+// New builds a Scheduler with totalCapacity worker slots shared across all
+// tenants and priorities.
+func New(config *Config, totalCapacity int) (*Scheduler, error) {
+	if totalCapacity <= 0 {
+		return nil, fmt.Errorf("totalCapacity must be positive, got %d", totalCapacity)
+	}
+	rules, err := compileRules(config.Rules)
+	if err != nil {
+		return nil, err
+	}
+	tenantHeader := config.TenantHeader
+	if tenantHeader == "" {
+		tenantHeader = "X-Scope-OrgID"
+	}
+
+	reservedByPriority := make(map[int]int)
+	for _, r := range rules {
+		slots, err := resolveReservedSlots(r.reservedSlots, totalCapacity)
+		if err != nil {
+			return nil, err
+		}
+		reservedByPriority[r.priority] += slots
+	}
+	priorities := make([]int, 0, len(reservedByPriority))
+	for p := range reservedByPriority {
+		priorities = append(priorities, p)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
+
+	tiers := make([]tier, 0, len(priorities))
+	reservedAtOrAbove := 0
+	for _, p := range priorities {
+		reservedAtOrAbove += reservedByPriority[p]
+		capacity := totalCapacity - reservedAtOrAbove
+		if capacity < 0 {
+			capacity = 0
+		}
+		tiers = append(tiers, tier{priority: p, sem: semaphore.NewWeighted(int64(capacity))})
+	}
+
+	return &Scheduler{
+		tenantHeader:         tenantHeader,
+		maxInFlightPerTenant: int64(config.MaxInFlightPerTenant),
+		rules:                rules,
+		tiers:                tiers,
+		global:               semaphore.NewWeighted(int64(totalCapacity)),
+		tenantSems:           make(map[string]*semaphore.Weighted),
+	}, nil
+}
+
+// TenantHeader returns the HTTP header this Scheduler reads tenant IDs
+// from.
+func (s *Scheduler) TenantHeader() string {
+	return s.tenantHeader
+}
+
+// Classify assigns a priority and queue timeout to a query based on the
+// configured rules. The first rule whose regex matches query and whose
+// duration bounds contain end-start wins; a query matching no rule gets
+// priority 0 and no timeout.
+func (s *Scheduler) Classify(query string, start, end float64) (priority int, timeout time.Duration) {
+	duration := time.Duration((end - start) * float64(time.Second))
+	for _, r := range s.rules {
+		if !r.regex.MatchString(query) {
+			continue
+		}
+		if r.minDuration != 0 && duration < r.minDuration {
+			continue
+		}
+		if r.maxDuration != 0 && duration > r.maxDuration {
+			continue
+		}
+		return r.priority, r.timeout
+	}
+	return 0, 0
+}
+
+// Schedule blocks until tenant is admitted a worker slot for a query of
+// the given priority, or until timeout elapses or ctx is canceled. On
+// success it returns a release function the caller must call exactly once
+// when the query is done. On failure it returns a *SchedulingError.
+func (s *Scheduler) Schedule(ctx context.Context, tenant string, priority int, timeout time.Duration) (
+	release func(), err error) {
+
+	priorityLabel := strconv.Itoa(priority)
+	queueDepth.WithLabelValues(tenant, priorityLabel).Inc()
+	defer queueDepth.WithLabelValues(tenant, priorityLabel).Dec()
+	waitStart := time.Now()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var acquired []func()
+	rollback := func() {
+		for i := len(acquired) - 1; i >= 0; i-- {
+			acquired[i]()
+		}
+	}
+
+	if s.maxInFlightPerTenant > 0 {
+		tenantSem := s.tenantSemaphore(tenant)
+		if err := tenantSem.Acquire(ctx, 1); err != nil {
+			return nil, s.reject(tenant, priorityLabel, waitStart, err)
+		}
+		acquired = append(acquired, func() { tenantSem.Release(1) })
+	}
+
+	for _, t := range s.tiers {
+		if t.priority <= priority {
+			continue
+		}
+		if err := t.sem.Acquire(ctx, 1); err != nil {
+			rollback()
+			return nil, s.reject(tenant, priorityLabel, waitStart, err)
+		}
+		acquired = append(acquired, func(sem *semaphore.Weighted) func() {
+			return func() { sem.Release(1) }
+		}(t.sem))
+	}
+
+	if err := s.global.Acquire(ctx, 1); err != nil {
+		rollback()
+		return nil, s.reject(tenant, priorityLabel, waitStart, err)
+	}
+	acquired = append(acquired, func() { s.global.Release(1) })
+
+	waitSeconds.WithLabelValues(tenant, priorityLabel).Observe(time.Since(waitStart).Seconds())
+	return rollback, nil
+}
+
+func (s *Scheduler) reject(tenant, priorityLabel string, waitStart time.Time, err error) *SchedulingError {
+	waitSeconds.WithLabelValues(tenant, priorityLabel).Observe(time.Since(waitStart).Seconds())
+	reason := "canceled"
+	if errors.Is(err, context.DeadlineExceeded) {
+		reason = "timeout"
+	}
+	rejectionsTotal.WithLabelValues(tenant, priorityLabel, reason).Inc()
+	return &SchedulingError{
+		Reason: reason,
+		Err:    fmt.Sprintf("query %s waiting for a worker slot", reason),
+	}
+}
+
+func (s *Scheduler) tenantSemaphore(tenant string) *semaphore.Weighted {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sem, ok := s.tenantSems[tenant]
+	if !ok {
+		sem = semaphore.NewWeighted(s.maxInFlightPerTenant)
+		s.tenantSems[tenant] = sem
+	}
+	return sem
+}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClassify(t *testing.T) {
+	config := &Config{
+		Rules: []RuleConfig{
+			{Regex: `^alert\(`, Priority: 10, Timeout: time.Second},
+			{Regex: ``, MinDuration: time.Hour, Priority: 1, Timeout: 5 * time.Second},
+		},
+	}
+	s, err := New(config, 10)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	priority, timeout := s.Classify(`alert(foo)`, 0, 10)
+	if priority != 10 || timeout != time.Second {
+		t.Errorf("Classify(alert query) = (%d, %v); want (10, 1s)", priority, timeout)
+	}
+
+	priority, timeout = s.Classify(`ts(some.metric)`, 0, 7200)
+	if priority != 1 || timeout != 5*time.Second {
+		t.Errorf("Classify(long dashboard query) = (%d, %v); want (1, 5s)", priority, timeout)
+	}
+
+	priority, timeout = s.Classify(`ts(some.metric)`, 0, 10)
+	if priority != 0 || timeout != 0 {
+		t.Errorf("Classify(unmatched query) = (%d, %v); want (0, 0)", priority, timeout)
+	}
+}
+
+func TestScheduleAdmitsUpToCapacity(t *testing.T) {
+	s, err := New(&Config{}, 2)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	release1, err := s.Schedule(context.Background(), "tenant-a", 0, 0)
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	release2, err := s.Schedule(context.Background(), "tenant-a", 0, 0)
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := s.Schedule(ctx, "tenant-a", 0, 0); err == nil {
+		t.Fatal("expected Schedule() to block past capacity")
+	} else if schedErr, ok := err.(*SchedulingError); !ok || schedErr.Reason != "timeout" {
+		t.Errorf("err = %v; want a timeout SchedulingError", err)
+	}
+
+	release1()
+	release2()
+}
+
+func TestScheduleReservesSlotsForHigherPriority(t *testing.T) {
+	config := &Config{
+		Rules: []RuleConfig{
+			{Priority: 10, ReservedSlots: "1"},
+		},
+	}
+	s, err := New(config, 2)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// The low priority request should only ever be able to take the one
+	// slot that isn't reserved for priority 10.
+	release1, err := s.Schedule(context.Background(), "tenant-a", 0, 0)
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	defer release1()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := s.Schedule(ctx, "tenant-a", 0, 0); err == nil {
+		t.Fatal("expected low priority Schedule() to be blocked by the reserved slot")
+	}
+
+	// But a priority 10 request can still get in, using the reserved slot.
+	release2, err := s.Schedule(context.Background(), "tenant-a", 10, 0)
+	if err != nil {
+		t.Fatalf("Schedule() for reserved priority error = %v", err)
+	}
+	release2()
+}
+
+func TestScheduleEnforcesPerTenantLimit(t *testing.T) {
+	s, err := New(&Config{MaxInFlightPerTenant: 1}, 10)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	release, err := s.Schedule(context.Background(), "tenant-a", 0, 0)
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := s.Schedule(ctx, "tenant-a", 0, 0); err == nil {
+		t.Fatal("expected second request from the same tenant to be blocked")
+	}
+
+	// A different tenant is unaffected by tenant-a's limit.
+	release2, err := s.Schedule(context.Background(), "tenant-b", 0, 0)
+	if err != nil {
+		t.Fatalf("Schedule() for different tenant error = %v", err)
+	}
+	release2()
+}
@@ -0,0 +1,96 @@
+// Package client is a Go client for this proxy's gRPC Query API, letting
+// downstream services embed the proxy directly instead of shelling out to
+// its HTTP API.
+package client
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	queryv1 "github.com/keep94/wavefront_promql_proxy/proto/query/v1"
+)
+
+// Client wraps a gRPC connection to a wavefront_promql_proxy server.
+type Client struct {
+	conn *grpc.ClientConn
+	api  queryv1.QueryClient
+}
+
+// Dial connects to a wavefront_promql_proxy gRPC server at address.
+func Dial(address string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, api: queryv1.NewQueryClient(conn)}, nil
+}
+
+// Close closes the underlying gRPC connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Query runs an instant query and returns every series along with the
+// final Stats frame the server sends.
+func (c *Client) Query(ctx context.Context, query string, at float64) (
+	[]*queryv1.Series, *queryv1.Stats, error) {
+
+	stream, err := c.api.Query(ctx, &queryv1.QueryRequest{Query: query, Time: at})
+	if err != nil {
+		return nil, nil, err
+	}
+	var series []*queryv1.Series
+	var stats *queryv1.Stats
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return series, stats, normalizeEOF(err)
+		}
+		switch result := msg.Result.(type) {
+		case *queryv1.QueryResponse_Series:
+			series = append(series, result.Series)
+		case *queryv1.QueryResponse_Stats:
+			stats = result.Stats
+		case *queryv1.QueryResponse_Warning:
+			return series, stats, errors.New(result.Warning)
+		}
+	}
+}
+
+// QueryRange runs a ranged query and returns every series along with the
+// final Stats frame the server sends.
+func (c *Client) QueryRange(ctx context.Context, query string, start, end, step float64) (
+	[]*queryv1.Series, *queryv1.Stats, error) {
+
+	stream, err := c.api.QueryRange(ctx, &queryv1.QueryRangeRequest{
+		Query: query,
+		Start: start,
+		End:   end,
+		Step:  step,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var series []*queryv1.Series
+	var stats *queryv1.Stats
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return series, stats, normalizeEOF(err)
+		}
+		switch result := msg.Result.(type) {
+		case *queryv1.QueryRangeResponse_Series:
+			series = append(series, result.Series)
+		case *queryv1.QueryRangeResponse_Stats:
+			stats = result.Stats
+		case *queryv1.QueryRangeResponse_Warning:
+			return series, stats, errors.New(result.Warning)
+		}
+	}
+}
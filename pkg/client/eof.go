@@ -0,0 +1,13 @@
+package client
+
+import "io"
+
+// normalizeEOF turns the io.EOF a gRPC stream's Recv returns at the end of
+// a normal stream into nil, since that's not an error from the caller's
+// point of view.
+func normalizeEOF(err error) error {
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}